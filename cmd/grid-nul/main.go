@@ -0,0 +1,330 @@
+// Command grid-nul scans a directory tree for reserved-name artifacts
+// (nul, con, prn, aux, com*, lpt*) and acts on what it finds. It replaces
+// the two programs this repo used to carry — scripts/nul_guard's
+// flag-driven one-shot scanner and an archived interactive security
+// monitor — with a single binary backed by pkg/nulscan, selecting among
+// actions via explicit subcommands instead of an interactive prompt so
+// it's safe to run unattended in CI.
+package main
+
+import (
+    "context"
+    "flag"
+    "fmt"
+    "log"
+    "os"
+    "os/signal"
+    "syscall"
+
+    "github.com/GRID-INTELLIGENCE/GRID/pkg/nulscan"
+)
+
+// controlSocketEnv is set in this process's own environment once the
+// control socket is listening, for the benefit of any child process this
+// daemon itself spawns. It does not propagate to a separately launched
+// companion CLI — os.Setenv only ever mutates the calling process's own
+// environment — so a companion CLI should instead read
+// nulscan.ControlServer.SocketPathFile() (a root-scoped discovery file
+// under --quarantine-root).
+const controlSocketEnv = "GRID_NUL_SOCK"
+
+func main() {
+    if len(os.Args) < 2 {
+        usage()
+        os.Exit(2)
+    }
+
+    ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer cancel()
+
+    var err error
+    switch os.Args[1] {
+    case "scan":
+        err = runScan(ctx, os.Args[2:])
+    case "clean":
+        err = runClean(ctx, os.Args[2:])
+    case "quarantine":
+        err = runQuarantine(ctx, os.Args[2:])
+    case "watch":
+        err = runWatch(ctx, os.Args[2:])
+    case "report":
+        err = runReport(os.Args[2:])
+    default:
+        usage()
+        os.Exit(2)
+    }
+    if err != nil {
+        log.Fatalf("%s failed: %v", os.Args[1], err)
+    }
+}
+
+func usage() {
+    fmt.Fprintln(os.Stderr, "usage: grid-nul <scan|clean|quarantine|watch|report> [flags]")
+}
+
+// loadEngine returns the pattern engine patternsPath describes, or the
+// built-in reserved-device-name engine if patternsPath is empty.
+func loadEngine(patternsPath string) (*nulscan.Engine, error) {
+    if patternsPath == "" {
+        return nulscan.DefaultEngine(), nil
+    }
+    return nulscan.LoadPatterns(patternsPath)
+}
+
+// runScan performs a single sweep and reports what it found without
+// touching any of it; it takes no --dry-run flag since it never acts.
+func runScan(ctx context.Context, args []string) error {
+    fs := flag.NewFlagSet("scan", flag.ExitOnError)
+    root := fs.String("root", ".", "Root directory to inspect")
+    blocklistPath := fs.String("blocklist", "reports/nul_blocklist.rec", "Where to persist the append-only blocklist ledger")
+    patternsPath := fs.String("patterns", "", "patterns.yaml describing the glob/regex/reserved-name rules to scan for (defaults to the built-in reserved-device-name set)")
+    if err := fs.Parse(args); err != nil {
+        return err
+    }
+
+    engine, err := loadEngine(*patternsPath)
+    if err != nil {
+        return fmt.Errorf("load patterns: %w", err)
+    }
+
+    occs, err := (&nulscan.Scanner{Root: *root, Engine: engine}).Scan(ctx)
+    if err != nil {
+        return err
+    }
+    reportOccurrences(occs)
+
+    buildUUID := nulscan.NewBuildUUID()
+    if err := nulscan.EnsureBlocklist(*blocklistPath, occs, "dry-run", buildUUID, engine.PatternStrings()); err != nil {
+        log.Printf("warning: unable to refresh blocklist ledger: %v", err)
+    }
+    return nulscan.EnsureGitExclude(*root)
+}
+
+// runClean sweeps root and deletes every occurrence it finds.
+func runClean(ctx context.Context, args []string) error {
+    fs := flag.NewFlagSet("clean", flag.ExitOnError)
+    root := fs.String("root", ".", "Root directory to inspect")
+    blocklistPath := fs.String("blocklist", "reports/nul_blocklist.rec", "Where to persist the append-only blocklist ledger")
+    dryRun := fs.Bool("dry-run", false, "Scan without deleting any files")
+    concurrency := fs.Int("workers", 8, "Maximum concurrent removals")
+    watch := fs.Bool("watch", false, "After the initial sweep, keep running and react to new artifacts as they appear")
+    quarantineRoot := fs.String("quarantine-root", os.TempDir(), "Directory the control socket's QUARANTINE verb moves artifacts under")
+    controlSocket := fs.Bool("control-socket", true, "With --watch, serve a Unix-socket control plane (see GRID_NUL_SOCK)")
+    patternsPath := fs.String("patterns", "", "patterns.yaml describing the glob/regex/reserved-name rules to scan for (defaults to the built-in reserved-device-name set)")
+    if err := fs.Parse(args); err != nil {
+        return err
+    }
+
+    engine, err := loadEngine(*patternsPath)
+    if err != nil {
+        return fmt.Errorf("load patterns: %w", err)
+    }
+
+    action, label := actionForDryRun(*dryRun, nulscan.DeleteAction{}, "removed")
+    return sweepAndMaybeWatch(ctx, *root, *blocklistPath, *quarantineRoot, engine, action, label, *concurrency, *watch, *controlSocket)
+}
+
+// runQuarantine sweeps root and moves every occurrence into a
+// build-UUID-namespaced quarantine directory instead of deleting it.
+func runQuarantine(ctx context.Context, args []string) error {
+    fs := flag.NewFlagSet("quarantine", flag.ExitOnError)
+    root := fs.String("root", ".", "Root directory to inspect")
+    blocklistPath := fs.String("blocklist", "reports/nul_blocklist.rec", "Where to persist the append-only blocklist ledger")
+    quarantineRoot := fs.String("quarantine-root", os.TempDir(), "Directory quarantined artifacts are namespaced and moved under")
+    concurrency := fs.Int("workers", 8, "Maximum concurrent quarantines")
+    watch := fs.Bool("watch", false, "After the initial sweep, keep running and react to new artifacts as they appear")
+    controlSocket := fs.Bool("control-socket", true, "With --watch, serve a Unix-socket control plane (see GRID_NUL_SOCK)")
+    patternsPath := fs.String("patterns", "", "patterns.yaml describing the glob/regex/reserved-name rules to scan for (defaults to the built-in reserved-device-name set)")
+    if err := fs.Parse(args); err != nil {
+        return err
+    }
+
+    engine, err := loadEngine(*patternsPath)
+    if err != nil {
+        return fmt.Errorf("load patterns: %w", err)
+    }
+
+    buildUUID := nulscan.NewBuildUUID()
+    action := nulscan.QuarantineAction{Root: *quarantineRoot, BuildUUID: buildUUID}
+    return sweepAndMaybeWatchWithUUID(ctx, *root, *blocklistPath, *quarantineRoot, engine, action, "quarantined", *concurrency, *watch, *controlSocket, buildUUID)
+}
+
+// runWatch runs no separate sweep of its own: it starts the watcher, whose
+// initial registration walk reports each artifact that already exists once,
+// and then reacts to artifacts as they're created, deleting each one it's
+// handed.
+func runWatch(ctx context.Context, args []string) error {
+    fs := flag.NewFlagSet("watch", flag.ExitOnError)
+    root := fs.String("root", ".", "Root directory to watch")
+    blocklistPath := fs.String("blocklist", "reports/nul_blocklist.rec", "Where to persist the append-only blocklist ledger")
+    dryRun := fs.Bool("dry-run", false, "Watch without deleting any files")
+    concurrency := fs.Int("workers", 8, "Maximum concurrent removals")
+    quarantineRoot := fs.String("quarantine-root", os.TempDir(), "Directory the control socket's QUARANTINE verb moves artifacts under")
+    controlSocket := fs.Bool("control-socket", true, "Serve a Unix-socket control plane alongside the watch loop (see GRID_NUL_SOCK)")
+    patternsPath := fs.String("patterns", "", "patterns.yaml describing the glob/regex/reserved-name rules to watch for (defaults to the built-in reserved-device-name set)")
+    if err := fs.Parse(args); err != nil {
+        return err
+    }
+
+    engine, err := loadEngine(*patternsPath)
+    if err != nil {
+        return fmt.Errorf("load patterns: %w", err)
+    }
+
+    action, label := actionForDryRun(*dryRun, nulscan.DeleteAction{}, "removed")
+    return watchLoop(ctx, *root, *blocklistPath, *quarantineRoot, engine, action, label, *concurrency, *controlSocket)
+}
+
+// runReport streams ledger records back out, optionally filtered to those
+// detected at or after --since.
+func runReport(args []string) error {
+    fs := flag.NewFlagSet("report", flag.ExitOnError)
+    blocklistPath := fs.String("blocklist", "reports/nul_blocklist.rec", "Ledger to read")
+    since := fs.String("since", "", "Only show records detected at or after this TAI64N label (e.g. @4000000037c219bf2ef02e94)")
+    if err := fs.Parse(args); err != nil {
+        return err
+    }
+
+    _, records, err := nulscan.ReadLedger(*blocklistPath)
+    if err != nil {
+        return err
+    }
+    filtered, err := nulscan.FilterSince(records, *since)
+    if err != nil {
+        return fmt.Errorf("parse --since: %w", err)
+    }
+
+    for _, rec := range filtered {
+        log.Printf("%s  %-9s  %s  (parent=%s top=%s build=%s)", rec.TAI64N, rec.Action, rec.Path, rec.Parent, rec.Top, rec.BuildUUID)
+    }
+    return nil
+}
+
+func actionForDryRun(dryRun bool, real nulscan.Action, realLabel string) (nulscan.Action, string) {
+    if dryRun {
+        return nulscan.ReportOnlyAction{}, "dry-run"
+    }
+    return real, realLabel
+}
+
+func sweepAndMaybeWatch(ctx context.Context, root, blocklistPath, quarantineRoot string, engine *nulscan.Engine, action nulscan.Action, label string, concurrency int, watch, controlSocket bool) error {
+    return sweepAndMaybeWatchWithUUID(ctx, root, blocklistPath, quarantineRoot, engine, action, label, concurrency, watch, controlSocket, nulscan.NewBuildUUID())
+}
+
+func sweepAndMaybeWatchWithUUID(ctx context.Context, root, blocklistPath, quarantineRoot string, engine *nulscan.Engine, action nulscan.Action, label string, concurrency int, watch, controlSocket bool, buildUUID string) error {
+    occs, err := (&nulscan.Scanner{Root: root, Engine: engine}).Scan(ctx)
+    if err != nil {
+        return err
+    }
+
+    if len(occs) == 0 {
+        log.Println("No reserved-name artifacts detected.")
+    } else {
+        log.Printf("Detected %d reserved-name artifacts\n", len(occs))
+        reportOccurrences(occs)
+
+        if _, err := nulscan.ApplyAll(ctx, occs, action, concurrency); err != nil {
+            return fmt.Errorf("apply %s: %w", label, err)
+        }
+        log.Printf("All reserved-name artifacts %s.", label)
+    }
+
+    if err := nulscan.EnsureBlocklist(blocklistPath, occs, label, buildUUID, engine.PatternStrings()); err != nil {
+        return fmt.Errorf("blocklist ledger update failed: %w", err)
+    }
+    if err := nulscan.EnsureGitExclude(root); err != nil {
+        return fmt.Errorf("failed to enforce git exclude: %w", err)
+    }
+
+    if !watch {
+        return nil
+    }
+    return watchLoop(ctx, root, blocklistPath, quarantineRoot, engine, action, label, concurrency, controlSocket)
+}
+
+// watchLoop keeps the one-shot sweep above usable on its own while adding
+// a daemon mode on top: it watches the tree for newly created artifacts
+// and enqueues each one onto action as it's detected, rather than waiting
+// for the next invocation. When controlSocket is set it also serves a
+// Unix-socket control plane alongside the watch loop so a companion CLI
+// can query STATUS/LIST or drive SCAN/QUARANTINE/RESTORE/PATTERNS/SHUTDOWN
+// without racing the watcher's own filesystem walk. The control server
+// shares this loop's *Engine, so PATTERNS ADD|DEL over the socket affects
+// both; and every occurrence this loop relocates on its own is registered
+// into the control server's quarantine table, so LIST/RESTORE see it too.
+func watchLoop(ctx context.Context, root, blocklistPath, quarantineRoot string, engine *nulscan.Engine, action nulscan.Action, label string, concurrency int, controlSocket bool) error {
+    events, stop, err := nulscan.Watch(root, engine.Matcher())
+    if err != nil {
+        return fmt.Errorf("start watcher: %w", err)
+    }
+    defer stop()
+
+    log.Printf("Watching %s for reserved-name artifacts (Ctrl+C to stop)...", root)
+    buildUUID := nulscan.NewBuildUUID()
+
+    var ctrl *nulscan.ControlServer
+    var shutdown <-chan struct{}
+    if controlSocket {
+        var err error
+        ctrl, err = nulscan.NewControlServer(root, blocklistPath, quarantineRoot, buildUUID, engine)
+        if err != nil {
+            log.Printf("warning: control socket disabled: %v", err)
+            ctrl = nil
+        } else {
+            defer ctrl.Close()
+            if err := os.Setenv(controlSocketEnv, ctrl.SocketPath()); err != nil {
+                log.Printf("warning: unable to export %s: %v", controlSocketEnv, err)
+            }
+            log.Printf("Control socket listening at %s (path published to %s)", ctrl.SocketPath(), ctrl.SocketPathFile())
+            go func() {
+                if err := ctrl.Serve(ctx); err != nil {
+                    log.Printf("control socket error: %v", err)
+                }
+            }()
+            shutdown = ctrl.Shutdown
+        }
+    }
+
+    var seen []nulscan.Occurrence
+    for {
+        select {
+        case <-ctx.Done():
+            return nil
+        case <-shutdown:
+            log.Println("Shutdown requested over control socket.")
+            return nil
+        case ev, ok := <-events:
+            if !ok {
+                return nil
+            }
+            if ev.Err != nil {
+                log.Printf("watch error: %v", ev.Err)
+                continue
+            }
+
+            seen = append(seen, ev.Occurrence)
+            log.Printf("Detected new reserved-name artifact: %s", ev.Occurrence.Path)
+            nulscan.SummarizeSources(seen, log.Printf)
+
+            applied, err := nulscan.ApplyAll(ctx, []nulscan.Occurrence{ev.Occurrence}, action, concurrency)
+            if err != nil {
+                log.Printf("failed to %s %s: %v", label, ev.Occurrence.Path, err)
+                continue
+            }
+            if ctrl != nil {
+                for _, a := range applied {
+                    if a.Dest != "" {
+                        ctrl.TrackQuarantine(a.Occurrence, a.Dest)
+                    }
+                }
+            }
+            if err := nulscan.EnsureBlocklist(blocklistPath, []nulscan.Occurrence{ev.Occurrence}, label, buildUUID, engine.PatternStrings()); err != nil {
+                log.Printf("warning: unable to refresh blocklist ledger: %v", err)
+            }
+        }
+    }
+}
+
+func reportOccurrences(occs []nulscan.Occurrence) {
+    nulscan.SummarizeSources(occs, log.Printf)
+}
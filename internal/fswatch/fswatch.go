@@ -0,0 +1,63 @@
+// Package fswatch provides a platform-neutral filesystem watcher for
+// grid-nul's daemon mode. It detects reserved-name artifacts the moment
+// they are created instead of waiting on the next full filepath.WalkDir
+// sweep, while still sharing the Occurrence shape the one-off scanner
+// already produces.
+//
+// Each supported OS gets its own native-watcher constructor (inotify on
+// Linux, kqueue on BSD/macOS, ReadDirectoryChangesW on Windows); the
+// recursive registration, debounce, and rescan-fallback logic above it is
+// shared so callers never branch on GOOS.
+package fswatch
+
+import "time"
+
+// Occurrence mirrors the scanner's Occurrence type so watch events can be
+// enqueued onto the same removal pipeline as a one-off scan.
+type Occurrence struct {
+    Path   string
+    Parent string
+    Top    string
+}
+
+// Matcher reports whether a file name should be surfaced as an Occurrence.
+type Matcher func(name string) bool
+
+// Watcher recursively observes a directory tree and emits an Occurrence
+// each time a matching file is created.
+type Watcher interface {
+    // Events returns the channel Occurrences are delivered on. It is
+    // closed once Close has fully torn the watcher down.
+    Events() <-chan Occurrence
+    // Errors returns the channel non-fatal watch errors are delivered on.
+    Errors() <-chan error
+    // Add recursively registers root and every directory beneath it.
+    Add(root string) error
+    // Close stops the watcher and releases its resources.
+    Close() error
+}
+
+// Options configures a Watcher.
+type Options struct {
+    // Matcher decides which file names are worth reporting. Required.
+    Matcher Matcher
+    // DebounceWindow collapses rapid create+delete churn on the same path
+    // into a single Occurrence. Defaults to 150ms.
+    DebounceWindow time.Duration
+    // RescanInterval drives the periodic fallback scan used to paper over
+    // transient EBADF/ENOSPC errors from the native event source.
+    // Defaults to 5 minutes.
+    RescanInterval time.Duration
+}
+
+// New starts a recursive Watcher backed by the current platform's native
+// event source. Call Add for each root that should be observed.
+func New(opts Options) (Watcher, error) {
+    if opts.DebounceWindow <= 0 {
+        opts.DebounceWindow = 150 * time.Millisecond
+    }
+    if opts.RescanInterval <= 0 {
+        opts.RescanInterval = 5 * time.Minute
+    }
+    return newRecursiveWatcher(opts)
+}
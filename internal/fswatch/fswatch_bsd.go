@@ -0,0 +1,58 @@
+//go:build darwin || freebsd || netbsd || openbsd
+
+package fswatch
+
+import "github.com/fsnotify/fsnotify"
+
+// kqueueWatcher wraps fsnotify's kqueue backend, used on macOS and the BSDs.
+type kqueueWatcher struct {
+    fsw   *fsnotify.Watcher
+    evCh  chan nativeEvent
+    errCh chan error
+}
+
+func newNativeWatcher() (nativeWatcher, error) {
+    fsw, err := fsnotify.NewWatcher()
+    if err != nil {
+        return nil, err
+    }
+
+    w := &kqueueWatcher{
+        fsw:   fsw,
+        evCh:  make(chan nativeEvent, 128),
+        errCh: make(chan error, 16),
+    }
+    go w.translate()
+    return w, nil
+}
+
+func (w *kqueueWatcher) translate() {
+    defer close(w.evCh)
+    defer close(w.errCh)
+
+    for {
+        select {
+        case ev, ok := <-w.fsw.Events:
+            if !ok {
+                return
+            }
+            if ev.Op&fsnotify.Create != 0 {
+                w.evCh <- nativeEvent{path: ev.Name, created: true}
+            }
+            if ev.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+                w.evCh <- nativeEvent{path: ev.Name, removed: true}
+            }
+        case err, ok := <-w.fsw.Errors:
+            if !ok {
+                return
+            }
+            w.errCh <- err
+        }
+    }
+}
+
+func (w *kqueueWatcher) addDir(path string) error  { return w.fsw.Add(path) }
+func (w *kqueueWatcher) removeDir(path string)      { _ = w.fsw.Remove(path) }
+func (w *kqueueWatcher) events() <-chan nativeEvent { return w.evCh }
+func (w *kqueueWatcher) errors() <-chan error       { return w.errCh }
+func (w *kqueueWatcher) close() error               { return w.fsw.Close() }
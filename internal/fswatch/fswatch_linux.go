@@ -0,0 +1,72 @@
+//go:build linux
+
+package fswatch
+
+import (
+    "errors"
+    "syscall"
+
+    "github.com/fsnotify/fsnotify"
+)
+
+// inotifyWatcher wraps fsnotify's inotify backend. EBADF and ENOSPC (the
+// latter typically means fs.inotify.max_user_watches was exhausted) are
+// surfaced as regular errors rather than killing the watch loop, since
+// recursiveWatcher's periodic rescan covers for the gap.
+type inotifyWatcher struct {
+    fsw      *fsnotify.Watcher
+    evCh     chan nativeEvent
+    errCh    chan error
+}
+
+func newNativeWatcher() (nativeWatcher, error) {
+    fsw, err := fsnotify.NewWatcher()
+    if err != nil {
+        return nil, err
+    }
+
+    w := &inotifyWatcher{
+        fsw:   fsw,
+        evCh:  make(chan nativeEvent, 128),
+        errCh: make(chan error, 16),
+    }
+    go w.translate()
+    return w, nil
+}
+
+func (w *inotifyWatcher) translate() {
+    defer close(w.evCh)
+    defer close(w.errCh)
+
+    for {
+        select {
+        case ev, ok := <-w.fsw.Events:
+            if !ok {
+                return
+            }
+            if ev.Op&(fsnotify.Create) != 0 {
+                w.evCh <- nativeEvent{path: ev.Name, created: true}
+            }
+            if ev.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+                w.evCh <- nativeEvent{path: ev.Name, removed: true}
+            }
+        case err, ok := <-w.fsw.Errors:
+            if !ok {
+                return
+            }
+            if errors.Is(err, syscall.EBADF) || errors.Is(err, syscall.ENOSPC) {
+                // Transient: the rescan fallback in recursiveWatcher will
+                // pick up anything missed until the next successful Add.
+                w.errCh <- err
+                continue
+            }
+            w.errCh <- err
+        }
+    }
+}
+
+func (w *inotifyWatcher) addDir(path string) error  { return w.fsw.Add(path) }
+func (w *inotifyWatcher) removeDir(path string)      { _ = w.fsw.Remove(path) }
+func (w *inotifyWatcher) events() <-chan nativeEvent { return w.evCh }
+func (w *inotifyWatcher) errors() <-chan error       { return w.errCh }
+func (w *inotifyWatcher) close() error               { return w.fsw.Close() }
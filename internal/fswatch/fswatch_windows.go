@@ -0,0 +1,58 @@
+//go:build windows
+
+package fswatch
+
+import "github.com/fsnotify/fsnotify"
+
+// readDirChangesWatcher wraps fsnotify's ReadDirectoryChangesW backend.
+type readDirChangesWatcher struct {
+    fsw   *fsnotify.Watcher
+    evCh  chan nativeEvent
+    errCh chan error
+}
+
+func newNativeWatcher() (nativeWatcher, error) {
+    fsw, err := fsnotify.NewWatcher()
+    if err != nil {
+        return nil, err
+    }
+
+    w := &readDirChangesWatcher{
+        fsw:   fsw,
+        evCh:  make(chan nativeEvent, 128),
+        errCh: make(chan error, 16),
+    }
+    go w.translate()
+    return w, nil
+}
+
+func (w *readDirChangesWatcher) translate() {
+    defer close(w.evCh)
+    defer close(w.errCh)
+
+    for {
+        select {
+        case ev, ok := <-w.fsw.Events:
+            if !ok {
+                return
+            }
+            if ev.Op&fsnotify.Create != 0 {
+                w.evCh <- nativeEvent{path: ev.Name, created: true}
+            }
+            if ev.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+                w.evCh <- nativeEvent{path: ev.Name, removed: true}
+            }
+        case err, ok := <-w.fsw.Errors:
+            if !ok {
+                return
+            }
+            w.errCh <- err
+        }
+    }
+}
+
+func (w *readDirChangesWatcher) addDir(path string) error  { return w.fsw.Add(path) }
+func (w *readDirChangesWatcher) removeDir(path string)      { _ = w.fsw.Remove(path) }
+func (w *readDirChangesWatcher) events() <-chan nativeEvent { return w.evCh }
+func (w *readDirChangesWatcher) errors() <-chan error       { return w.errCh }
+func (w *readDirChangesWatcher) close() error               { return w.fsw.Close() }
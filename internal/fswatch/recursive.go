@@ -0,0 +1,270 @@
+package fswatch
+
+import (
+    "io/fs"
+    "os"
+    "path/filepath"
+    "sync"
+    "time"
+)
+
+// nativeWatcher is the thin, OS-specific layer each fswatch_*.go file
+// implements. recursiveWatcher does the recursive bookkeeping, debounce,
+// and rescan fallback on top of it so that logic is written once.
+type nativeWatcher interface {
+    addDir(path string) error
+    removeDir(path string)
+    events() <-chan nativeEvent
+    errors() <-chan error
+    close() error
+}
+
+type nativeEvent struct {
+    path    string
+    created bool
+    removed bool
+}
+
+type recursiveWatcher struct {
+    opts Options
+
+    native nativeWatcher
+
+    outEvents chan Occurrence
+    outErrors chan error
+    done      chan struct{}
+
+    mu      sync.Mutex
+    pending map[string]*time.Timer
+    roots   []string
+    dirs    map[string]bool
+
+    closeOnce sync.Once
+}
+
+func newRecursiveWatcher(opts Options) (Watcher, error) {
+    native, err := newNativeWatcher()
+    if err != nil {
+        return nil, err
+    }
+
+    w := &recursiveWatcher{
+        opts:      opts,
+        native:    native,
+        outEvents: make(chan Occurrence, 64),
+        outErrors: make(chan error, 16),
+        done:      make(chan struct{}),
+        pending:   make(map[string]*time.Timer),
+        dirs:      make(map[string]bool),
+    }
+
+    go w.loop()
+    go w.rescanLoop()
+
+    return w, nil
+}
+
+func (w *recursiveWatcher) Events() <-chan Occurrence { return w.outEvents }
+func (w *recursiveWatcher) Errors() <-chan error      { return w.outErrors }
+
+// Add recursively registers root and every directory beneath it with the
+// native watcher, then performs an immediate sweep so artifacts that
+// already existed before the watch started are reported once.
+func (w *recursiveWatcher) Add(root string) error {
+    w.mu.Lock()
+    w.roots = append(w.roots, root)
+    w.mu.Unlock()
+
+    return filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+        if walkErr != nil {
+            return nil
+        }
+        if d.IsDir() {
+            if err := w.native.addDir(path); err != nil {
+                w.emitError(err)
+            } else {
+                w.mu.Lock()
+                w.dirs[path] = true
+                w.mu.Unlock()
+            }
+            return nil
+        }
+        if w.opts.Matcher(d.Name()) {
+            w.emit(path)
+        }
+        return nil
+    })
+}
+
+func (w *recursiveWatcher) Close() error {
+    var err error
+    w.closeOnce.Do(func() {
+        close(w.done)
+        err = w.native.close()
+        close(w.outEvents)
+        close(w.outErrors)
+    })
+    return err
+}
+
+func (w *recursiveWatcher) loop() {
+    for {
+        select {
+        case <-w.done:
+            return
+        case ev, ok := <-w.native.events():
+            if !ok {
+                return
+            }
+            if ev.created {
+                w.handleCreate(ev.path)
+            }
+            if ev.removed {
+                w.handleDelete(ev.path)
+            }
+        case err, ok := <-w.native.errors():
+            if !ok {
+                continue
+            }
+            w.emitError(err)
+        }
+    }
+}
+
+// handleCreate registers newly created directories so their contents are
+// watched too, and debounces rapid create+delete churn on the same path
+// before surfacing it as an Occurrence.
+func (w *recursiveWatcher) handleCreate(path string) {
+    info, err := filepath.EvalSymlinks(path)
+    if err == nil {
+        path = info
+    }
+
+    if fi, statErr := os.Stat(path); statErr == nil && fi.IsDir() {
+        if err := w.native.addDir(path); err != nil {
+            w.emitError(err)
+        } else {
+            w.mu.Lock()
+            w.dirs[path] = true
+            w.mu.Unlock()
+        }
+        return
+    }
+
+    name := filepath.Base(path)
+    if !w.opts.Matcher(name) {
+        return
+    }
+
+    w.mu.Lock()
+    if t, ok := w.pending[path]; ok {
+        t.Stop()
+    }
+    w.pending[path] = time.AfterFunc(w.opts.DebounceWindow, func() {
+        w.mu.Lock()
+        delete(w.pending, path)
+        w.mu.Unlock()
+        w.emit(path)
+    })
+    w.mu.Unlock()
+}
+
+// handleDelete unregisters path from the native watcher if it was a
+// directory we'd previously added, so a removed subtree doesn't leave a
+// dangling inotify watch behind (the kernel drops the watch on its own
+// once the directory is gone, but the descriptor and any still-pending
+// native-side state should be released explicitly rather than relying on
+// that side effect). Deleted files need no bookkeeping here: they were
+// never individually watched, only matched out of their parent directory's
+// events.
+func (w *recursiveWatcher) handleDelete(path string) {
+    w.mu.Lock()
+    _, ok := w.dirs[path]
+    if ok {
+        delete(w.dirs, path)
+    }
+    w.mu.Unlock()
+
+    if ok {
+        w.native.removeDir(path)
+    }
+}
+
+func (w *recursiveWatcher) emit(path string) {
+    parent := filepath.Dir(path)
+    top := parent
+
+    w.mu.Lock()
+    for _, root := range w.roots {
+        if rel, err := filepath.Rel(root, path); err == nil && !filepath.IsAbs(rel) {
+            parent = filepath.Dir(rel)
+            if parent == "." {
+                parent = root
+            }
+            top = rootComponent(rel)
+            break
+        }
+    }
+    w.mu.Unlock()
+
+    select {
+    case w.outEvents <- Occurrence{Path: path, Parent: parent, Top: top}:
+    case <-w.done:
+    }
+}
+
+func (w *recursiveWatcher) emitError(err error) {
+    select {
+    case w.outErrors <- err:
+    case <-w.done:
+    default:
+    }
+}
+
+// rescanLoop falls back to a periodic full walk so a transient EBADF or
+// ENOSPC from the native event source (common under inotify watch-limit
+// pressure) doesn't permanently blind the watcher to new artifacts.
+func (w *recursiveWatcher) rescanLoop() {
+    ticker := time.NewTicker(w.opts.RescanInterval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-w.done:
+            return
+        case <-ticker.C:
+            w.mu.Lock()
+            roots := append([]string(nil), w.roots...)
+            w.mu.Unlock()
+
+            for _, root := range roots {
+                _ = filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+                    if walkErr != nil || d.IsDir() {
+                        return nil
+                    }
+                    if w.opts.Matcher(d.Name()) {
+                        w.emit(path)
+                    }
+                    return nil
+                })
+            }
+        }
+    }
+}
+
+func rootComponent(rel string) string {
+    rel = filepath.ToSlash(rel)
+    if idx := indexByte(rel, '/'); idx > 0 {
+        return rel[:idx]
+    }
+    return rel
+}
+
+func indexByte(s string, b byte) int {
+    for i := 0; i < len(s); i++ {
+        if s[i] == b {
+            return i
+        }
+    }
+    return -1
+}
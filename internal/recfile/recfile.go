@@ -0,0 +1,93 @@
+// Package recfile implements the minimal subset of the GNU recfile
+// plain-text format (https://www.gnu.org/software/recutils/manual/Recfile-Format.html)
+// that pkg/nulscan's ledger needs: a record is a run of "Field: value"
+// lines, and records are separated from one another by a blank line.
+// It exists so the ledger doesn't depend on go.cypherpunks.ru/recfile,
+// which isn't reachable through this module's configured proxy.
+package recfile
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "sort"
+    "strings"
+)
+
+// Reader reads successive records from the underlying stream.
+type Reader struct {
+    scanner *bufio.Scanner
+}
+
+// NewReader wraps r as a recfile Reader.
+func NewReader(r io.Reader) *Reader {
+    return &Reader{scanner: bufio.NewScanner(r)}
+}
+
+// Read returns the next record as a field-name-to-value map, or io.EOF
+// once the stream is exhausted.
+func (r *Reader) Read() (map[string]string, error) {
+    fields := make(map[string]string)
+    sawLine := false
+    for r.scanner.Scan() {
+        line := r.scanner.Text()
+        if line == "" {
+            if sawLine {
+                return fields, nil
+            }
+            continue
+        }
+        sawLine = true
+        key, value, ok := strings.Cut(line, ": ")
+        if !ok {
+            return nil, fmt.Errorf("malformed record line %q", line)
+        }
+        fields[key] = value
+    }
+    if err := r.scanner.Err(); err != nil {
+        return nil, err
+    }
+    if sawLine {
+        return fields, nil
+    }
+    return nil, io.EOF
+}
+
+// Writer writes successive records, separating each from the next with a
+// blank line.
+type Writer struct {
+    w     *bufio.Writer
+    wrote bool
+}
+
+// NewWriter wraps w as a recfile Writer.
+func NewWriter(w io.Writer) *Writer {
+    return &Writer{w: bufio.NewWriter(w)}
+}
+
+// Write appends one record. Fields are emitted in sorted-key order so
+// output is deterministic regardless of map iteration order.
+func (wr *Writer) Write(fields map[string]string) error {
+    if wr.wrote {
+        if _, err := wr.w.WriteString("\n"); err != nil {
+            return err
+        }
+    }
+    wr.wrote = true
+
+    keys := make([]string, 0, len(fields))
+    for k := range fields {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+
+    for _, k := range keys {
+        if _, err := fmt.Fprintf(wr.w, "%s: %s\n", k, fields[k]); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// Flush flushes any buffered output to the underlying writer.
+func (wr *Writer) Flush() error { return wr.w.Flush() }
@@ -0,0 +1,54 @@
+package recfile
+
+import (
+    "bytes"
+    "io"
+    "testing"
+)
+
+func TestWriteReadRoundTrip(t *testing.T) {
+    var buf bytes.Buffer
+    w := NewWriter(&buf)
+
+    records := []map[string]string{
+        {"Path": "/tmp/nul", "Action": "removed"},
+        {"Path": "/tmp/sub/nul", "Action": "quarantined"},
+    }
+    for _, rec := range records {
+        if err := w.Write(rec); err != nil {
+            t.Fatalf("Write: %v", err)
+        }
+    }
+    if err := w.Flush(); err != nil {
+        t.Fatalf("Flush: %v", err)
+    }
+
+    r := NewReader(&buf)
+    for i, want := range records {
+        got, err := r.Read()
+        if err != nil {
+            t.Fatalf("Read record %d: %v", i, err)
+        }
+        if got["Path"] != want["Path"] || got["Action"] != want["Action"] {
+            t.Errorf("record %d = %v, want %v", i, got, want)
+        }
+    }
+
+    if _, err := r.Read(); err != io.EOF {
+        t.Fatalf("Read after last record: got err %v, want io.EOF", err)
+    }
+}
+
+func TestReadMalformedLine(t *testing.T) {
+    r := NewReader(bytes.NewBufferString("this is not a field\n"))
+    if _, err := r.Read(); err == nil {
+        t.Fatal("Read: expected error for malformed line, got nil")
+    }
+}
+
+func TestReadEmptyInput(t *testing.T) {
+    r := NewReader(bytes.NewBufferString(""))
+    if _, err := r.Read(); err != io.EOF {
+        t.Fatalf("Read: got err %v, want io.EOF", err)
+    }
+}
@@ -0,0 +1,47 @@
+package tai64n
+
+import (
+    "testing"
+    "time"
+)
+
+func TestStringParseRoundTrip(t *testing.T) {
+    now := time.Date(2026, 7, 29, 12, 0, 0, 123456789, time.UTC)
+    label := FromTime(now)
+
+    s := label.String()
+    if len(s) != 25 || s[0] != '@' {
+        t.Fatalf("String() = %q, want 25 chars starting with @", s)
+    }
+
+    parsed, err := Parse(s)
+    if err != nil {
+        t.Fatalf("Parse(%q): %v", s, err)
+    }
+    if parsed != label {
+        t.Errorf("Parse(%q) = %+v, want %+v", s, parsed, label)
+    }
+}
+
+func TestParseInvalid(t *testing.T) {
+    for _, s := range []string{"", "@short", "not-hex-but-24-chars-xyz", "@" + string(make([]byte, 30))} {
+        if _, err := Parse(s); err == nil {
+            t.Errorf("Parse(%q): expected error, got nil", s)
+        }
+    }
+}
+
+func TestBefore(t *testing.T) {
+    early := FromTime(time.Unix(1000, 0))
+    late := FromTime(time.Unix(2000, 0))
+
+    if !early.Before(late) {
+        t.Error("early.Before(late) = false, want true")
+    }
+    if late.Before(early) {
+        t.Error("late.Before(early) = true, want false")
+    }
+    if early.Before(early) {
+        t.Error("early.Before(early) = true, want false")
+    }
+}
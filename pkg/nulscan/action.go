@@ -0,0 +1,187 @@
+package nulscan
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "io"
+    "os"
+    "path/filepath"
+    "sync"
+    "time"
+)
+
+// Action decides what happens to a discovered Occurrence and reports the
+// ledger label (e.g. "removed", "quarantined") that should be recorded
+// for it. dest is the path the occurrence was moved to, for actions that
+// relocate rather than destroy it (e.g. QuarantineAction); it's empty for
+// actions that don't.
+type Action interface {
+    Apply(ctx context.Context, occ Occurrence) (label, dest string, err error)
+}
+
+// DeleteAction permanently removes the occurrence. This is the behavior
+// the original single-shot scanner had baked in.
+type DeleteAction struct{}
+
+func (DeleteAction) Apply(_ context.Context, occ Occurrence) (string, string, error) {
+    target, err := toExtendedPath(occ.Path)
+    if err != nil {
+        return "", "", fmt.Errorf("resolve %s: %w", occ.Path, err)
+    }
+    if err := os.RemoveAll(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+        return "", "", fmt.Errorf("remove %s: %w", occ.Path, err)
+    }
+    return "removed", "", nil
+}
+
+// QuarantineAction moves the occurrence into a namespaced quarantine
+// directory instead of deleting it outright. The directory is created
+// with 0700 permissions, mirroring the old SecurityMonitor's
+// quarantine-directory pattern, but the root is configurable and
+// namespaced per BuildUUID so concurrent runs don't collide.
+type QuarantineAction struct {
+    Root      string
+    BuildUUID string
+}
+
+func (q QuarantineAction) Apply(_ context.Context, occ Occurrence) (string, string, error) {
+    dir := filepath.Join(q.Root, q.BuildUUID)
+    if err := os.MkdirAll(dir, 0o700); err != nil {
+        return "", "", fmt.Errorf("create quarantine dir %s: %w", dir, err)
+    }
+
+    dest := filepath.Join(dir, fmt.Sprintf("%d_%s", time.Now().UnixNano(), filepath.Base(occ.Path)))
+    src, err := toExtendedPath(occ.Path)
+    if err != nil {
+        return "", "", fmt.Errorf("resolve %s: %w", occ.Path, err)
+    }
+    if err := os.Rename(src, dest); err != nil {
+        return "", "", fmt.Errorf("quarantine %s: %w", occ.Path, err)
+    }
+    return "quarantined", dest, nil
+}
+
+// ReportOnlyAction leaves the occurrence untouched. Used for --dry-run.
+type ReportOnlyAction struct{}
+
+func (ReportOnlyAction) Apply(_ context.Context, _ Occurrence) (string, string, error) {
+    return "dry-run", "", nil
+}
+
+// HashAndArchiveAction copies the occurrence into ArchiveRoot under its
+// sha256 digest before removing the original, so the content is
+// preserved for later inspection without leaving the artifact in place.
+type HashAndArchiveAction struct {
+    ArchiveRoot string
+    BuildUUID   string
+}
+
+func (h HashAndArchiveAction) Apply(_ context.Context, occ Occurrence) (string, string, error) {
+    target, err := toExtendedPath(occ.Path)
+    if err != nil {
+        return "", "", fmt.Errorf("resolve %s: %w", occ.Path, err)
+    }
+    src, err := os.Open(target)
+    if err != nil {
+        return "", "", fmt.Errorf("open %s: %w", occ.Path, err)
+    }
+    defer src.Close()
+
+    sum := sha256.New()
+    if _, err := io.Copy(sum, src); err != nil {
+        return "", "", fmt.Errorf("hash %s: %w", occ.Path, err)
+    }
+
+    dir := filepath.Join(h.ArchiveRoot, h.BuildUUID)
+    if err := os.MkdirAll(dir, 0o700); err != nil {
+        return "", "", fmt.Errorf("create archive dir %s: %w", dir, err)
+    }
+
+    dest := filepath.Join(dir, hex.EncodeToString(sum.Sum(nil)))
+    if _, err := src.Seek(0, io.SeekStart); err != nil {
+        return "", "", fmt.Errorf("rewind %s: %w", occ.Path, err)
+    }
+    out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+    if err != nil {
+        return "", "", fmt.Errorf("create %s: %w", dest, err)
+    }
+    if _, err := io.Copy(out, src); err != nil {
+        out.Close()
+        return "", "", fmt.Errorf("archive %s: %w", occ.Path, err)
+    }
+    if err := out.Close(); err != nil {
+        return "", "", err
+    }
+
+    if err := os.RemoveAll(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+        return "", "", fmt.Errorf("remove %s after archiving: %w", occ.Path, err)
+    }
+    return "archived", dest, nil
+}
+
+// Applied records the outcome of running an Action against an Occurrence.
+// Dest is the path the occurrence was relocated to, if the action moved it
+// rather than deleting or leaving it in place.
+type Applied struct {
+    Occurrence Occurrence
+    Label      string
+    Dest       string
+}
+
+// ApplyAll runs action against every occurrence using maxWorkers concurrent
+// goroutines, mirroring the worker-pool shape the original
+// removeOccurrences used.
+func ApplyAll(ctx context.Context, occs []Occurrence, action Action, maxWorkers int) ([]Applied, error) {
+    if maxWorkers < 1 {
+        maxWorkers = 1
+    }
+
+    jobs := make(chan Occurrence)
+    results := make(chan Applied, len(occs))
+    errChan := make(chan error, maxWorkers)
+    var wg sync.WaitGroup
+
+    worker := func() {
+        defer wg.Done()
+        for occ := range jobs {
+            select {
+            case <-ctx.Done():
+                return
+            default:
+            }
+            label, dest, err := action.Apply(ctx, occ)
+            if err != nil {
+                errChan <- err
+                return
+            }
+            results <- Applied{Occurrence: occ, Label: label, Dest: dest}
+        }
+    }
+
+    wg.Add(maxWorkers)
+    for i := 0; i < maxWorkers; i++ {
+        go worker()
+    }
+
+    for _, occ := range occs {
+        jobs <- occ
+    }
+    close(jobs)
+    wg.Wait()
+    close(results)
+
+    select {
+    case err := <-errChan:
+        return nil, err
+    default:
+    }
+
+    applied := make([]Applied, 0, len(occs))
+    for a := range results {
+        applied = append(applied, a)
+    }
+    return applied, nil
+}
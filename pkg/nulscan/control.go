@@ -0,0 +1,436 @@
+package nulscan
+
+import (
+    "bufio"
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "io"
+    "net"
+    "os"
+    "path/filepath"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+)
+
+// ControlServer exposes a line-framed protocol over a Unix-domain socket
+// so a companion CLI, editor, or pre-commit hook can query and drive a
+// running watch-mode daemon directly, analogous to an ssh-agent-style
+// proxy keyring, instead of spawning a fresh filepath.WalkDir every
+// invocation — which matters for large monorepos where a full walk is
+// prohibitive. One request per connection: a client writes a single verb
+// line, reads the response lines up to the blank terminator, and closes.
+//
+// Supported verbs: STATUS, SCAN <path>, QUARANTINE <path>, RESTORE <id>,
+// LIST, PATTERNS ADD|DEL <glob>, SHUTDOWN.
+type ControlServer struct {
+    Root           string
+    BlocklistPath  string
+    QuarantineRoot string
+    BuildUUID      string
+
+    listener     net.Listener
+    sockDir      string
+    sockPath     string
+    sockLinkPath string
+    started      time.Time
+
+    // Shutdown is closed when a client sends SHUTDOWN, so the process
+    // embedding the control server can tear down the rest of the daemon
+    // (the watch loop) alongside it.
+    Shutdown chan struct{}
+
+    mu          sync.Mutex
+    engine      *Engine
+    quarantined map[string]quarantinedEntry
+    nextID      int
+}
+
+type quarantinedEntry struct {
+    Occurrence Occurrence
+    Dest       string
+}
+
+// NewControlServer creates a private 0700 temp directory, binds a 0600
+// Unix-domain socket inside it, and returns a server ready for Serve.
+// The socket lives under a freshly generated temp directory, so it isn't
+// itself discoverable by name: NewControlServer also writes its path to
+// a well-known file under quarantineRoot (0600, created alongside it if
+// needed), which is the channel a companion CLI started against the
+// same root and --quarantine-root should actually read from instead of
+// racing a pidfile. That file's name is scoped to root (see
+// socketLinkName) rather than being a fixed "control.sock.path", since
+// quarantineRoot defaults to os.TempDir() — a directory every daemon on
+// the machine shares — and a fixed name would let two daemons watching
+// different trees overwrite each other's discovery file. engine is the
+// same pattern engine the daemon's own watch loop is scanning with, so
+// SCAN/QUARANTINE over the socket honor whatever --patterns configuration
+// the daemon was started with instead of silently falling back to
+// reserved-name-only matching; pass nil to use DefaultEngine.
+func NewControlServer(root, blocklistPath, quarantineRoot, buildUUID string, engine *Engine) (*ControlServer, error) {
+    if engine == nil {
+        engine = DefaultEngine()
+    }
+
+    sockDir, err := os.MkdirTemp("", "grid-nul-*")
+    if err != nil {
+        return nil, fmt.Errorf("create control socket dir: %w", err)
+    }
+    if err := os.Chmod(sockDir, 0o700); err != nil {
+        os.RemoveAll(sockDir)
+        return nil, fmt.Errorf("chmod control socket dir: %w", err)
+    }
+
+    sockPath := filepath.Join(sockDir, "control.sock")
+    l, err := net.Listen("unix", sockPath)
+    if err != nil {
+        os.RemoveAll(sockDir)
+        return nil, fmt.Errorf("bind control socket: %w", err)
+    }
+    if err := os.Chmod(sockPath, 0o600); err != nil {
+        l.Close()
+        os.RemoveAll(sockDir)
+        return nil, fmt.Errorf("chmod control socket: %w", err)
+    }
+
+    if err := os.MkdirAll(quarantineRoot, 0o700); err != nil {
+        l.Close()
+        os.RemoveAll(sockDir)
+        return nil, fmt.Errorf("create quarantine root %s: %w", quarantineRoot, err)
+    }
+    sockLinkPath := filepath.Join(quarantineRoot, socketLinkName(root))
+    if err := publishSocketPath(sockLinkPath, sockPath); err != nil {
+        l.Close()
+        os.RemoveAll(sockDir)
+        return nil, fmt.Errorf("publish control socket path: %w", err)
+    }
+
+    return &ControlServer{
+        Root:           root,
+        BlocklistPath:  blocklistPath,
+        QuarantineRoot: quarantineRoot,
+        BuildUUID:      buildUUID,
+        listener:       l,
+        sockDir:        sockDir,
+        sockPath:       sockPath,
+        sockLinkPath:   sockLinkPath,
+        started:        time.Now(),
+        Shutdown:       make(chan struct{}),
+        engine:         engine,
+        quarantined:    make(map[string]quarantinedEntry),
+    }, nil
+}
+
+// socketLinkName derives the discovery filename for root, so two daemons
+// sharing a quarantineRoot (e.g. the os.TempDir() default) but watching
+// different trees don't clobber each other's published socket path.
+func socketLinkName(root string) string {
+    abs, err := filepath.Abs(root)
+    if err != nil {
+        abs = root
+    }
+    sum := sha256.Sum256([]byte(abs))
+    return "control-" + hex.EncodeToString(sum[:8]) + ".sock.path"
+}
+
+// publishSocketPath writes sockPath into linkPath under an exclusive
+// advisory lock, so a concurrent publishSocketPath or
+// removeSocketPathIfOwned (from another daemon sharing the same
+// quarantineRoot and root) can't interleave with the write.
+func publishSocketPath(linkPath, sockPath string) error {
+    lf, err := os.OpenFile(linkPath, os.O_CREATE|os.O_RDWR, 0o600)
+    if err != nil {
+        return err
+    }
+    defer lf.Close()
+
+    unlock, err := lockFile(lf)
+    if err != nil {
+        return err
+    }
+    defer unlock()
+
+    if err := lf.Truncate(0); err != nil {
+        return err
+    }
+    _, err = lf.WriteAt([]byte(sockPath), 0)
+    return err
+}
+
+// removeSocketPathIfOwned removes linkPath, but only if — under the same
+// lock publishSocketPath takes — its contents still equal sockPath. This
+// closes the race a plain read-then-remove leaves open: without the
+// lock, a second daemon's publishSocketPath could land between the read
+// and the remove, and the first daemon would delete the second daemon's
+// just-published, still-live discovery file.
+func removeSocketPathIfOwned(linkPath, sockPath string) {
+    lf, err := os.OpenFile(linkPath, os.O_RDWR, 0o600)
+    if err != nil {
+        return
+    }
+    defer lf.Close()
+
+    unlock, err := lockFile(lf)
+    if err != nil {
+        return
+    }
+    defer unlock()
+
+    contents, err := io.ReadAll(lf)
+    if err != nil || string(contents) != sockPath {
+        return
+    }
+    os.Remove(linkPath)
+}
+
+// SocketPath returns the path the control socket is bound to.
+func (s *ControlServer) SocketPath() string { return s.sockPath }
+
+// SocketPathFile returns the well-known file a companion CLI started
+// with the same --quarantine-root can read to discover SocketPath.
+func (s *ControlServer) SocketPathFile() string { return s.sockLinkPath }
+
+// Serve accepts connections until ctx is done or Close is called.
+func (s *ControlServer) Serve(ctx context.Context) error {
+    go func() {
+        <-ctx.Done()
+        s.listener.Close()
+    }()
+
+    for {
+        conn, err := s.listener.Accept()
+        if err != nil {
+            select {
+            case <-ctx.Done():
+                return nil
+            default:
+                return err
+            }
+        }
+        go s.handle(conn)
+    }
+}
+
+// Close removes the socket and its private directory. The discovery file
+// SocketPathFile points at is removed too, but only if it still contains
+// this server's own SocketPath: quarantineRoot is commonly shared between
+// daemons (the os.TempDir() default), and a second daemon that started
+// against the same root since would have already overwritten it with its
+// own, still-live socket path.
+func (s *ControlServer) Close() error {
+    s.listener.Close()
+    removeSocketPathIfOwned(s.sockLinkPath, s.sockPath)
+    return os.RemoveAll(s.sockDir)
+}
+
+func (s *ControlServer) handle(conn net.Conn) {
+    defer conn.Close()
+
+    scanner := bufio.NewScanner(conn)
+    if !scanner.Scan() {
+        return
+    }
+
+    fields := strings.Fields(scanner.Text())
+    var lines []string
+    if len(fields) == 0 {
+        lines = []string{"ERR empty command"}
+    } else {
+        lines = s.dispatch(fields[0], fields[1:])
+    }
+
+    w := bufio.NewWriter(conn)
+    for _, line := range lines {
+        fmt.Fprintln(w, line)
+    }
+    fmt.Fprintln(w)
+    w.Flush()
+}
+
+func (s *ControlServer) dispatch(verb string, args []string) []string {
+    switch strings.ToUpper(verb) {
+    case "STATUS":
+        return s.handleStatus()
+    case "SCAN":
+        return s.handleScan(args)
+    case "QUARANTINE":
+        return s.handleQuarantine(args)
+    case "RESTORE":
+        return s.handleRestore(args)
+    case "LIST":
+        return s.handleList()
+    case "PATTERNS":
+        return s.handlePatterns(args)
+    case "SHUTDOWN":
+        close(s.Shutdown)
+        return []string{"OK shutting down"}
+    default:
+        return []string{fmt.Sprintf("ERR unknown verb %q", verb)}
+    }
+}
+
+// engineSnapshot returns a copy of the live pattern engine, so a scan can
+// range over a stable slice even while PATTERNS ADD/DEL is mutating it
+// concurrently on another connection.
+func (s *ControlServer) engineSnapshot() *Engine {
+    return &Engine{Patterns: s.engine.Snapshot()}
+}
+
+// TrackQuarantine registers a quarantine the caller already performed
+// (e.g. the watch loop's own auto-quarantine action) into the same
+// tracking table LIST/RESTORE read, returning the id it was assigned.
+func (s *ControlServer) TrackQuarantine(occ Occurrence, dest string) string {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    id := strconv.Itoa(s.nextID)
+    s.nextID++
+    s.quarantined[id] = quarantinedEntry{Occurrence: occ, Dest: dest}
+    return id
+}
+
+func (s *ControlServer) handleStatus() []string {
+    patterns := s.engine.Len()
+
+    s.mu.Lock()
+    quarantined := len(s.quarantined)
+    s.mu.Unlock()
+
+    return []string{
+        "OK",
+        fmt.Sprintf("root=%s", s.Root),
+        fmt.Sprintf("socket=%s", s.sockPath),
+        fmt.Sprintf("uptime=%s", time.Since(s.started).Round(time.Second)),
+        fmt.Sprintf("patterns=%d", patterns),
+        fmt.Sprintf("quarantined=%d", quarantined),
+    }
+}
+
+func (s *ControlServer) handleScan(args []string) []string {
+    root := s.Root
+    if len(args) > 0 {
+        root = args[0]
+    }
+
+    occs, err := (&Scanner{Root: root, Engine: s.engineSnapshot()}).Scan(context.Background())
+    if err != nil {
+        return []string{fmt.Sprintf("ERR %v", err)}
+    }
+
+    lines := []string{fmt.Sprintf("OK %d", len(occs))}
+    for _, occ := range occs {
+        lines = append(lines, occ.Path)
+    }
+    return lines
+}
+
+func (s *ControlServer) handleQuarantine(args []string) []string {
+    root := s.Root
+    if len(args) > 0 {
+        root = args[0]
+    }
+
+    occs, err := (&Scanner{Root: root, Engine: s.engineSnapshot()}).Scan(context.Background())
+    if err != nil {
+        return []string{fmt.Sprintf("ERR %v", err)}
+    }
+
+    dir := filepath.Join(s.QuarantineRoot, s.BuildUUID)
+    lines := []string{fmt.Sprintf("OK %d", len(occs))}
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    for _, occ := range occs {
+        if err := os.MkdirAll(dir, 0o700); err != nil {
+            lines = append(lines, fmt.Sprintf("ERR create quarantine dir %s: %v", dir, err))
+            continue
+        }
+
+        src, err := toExtendedPath(occ.Path)
+        if err != nil {
+            lines = append(lines, fmt.Sprintf("ERR %s: %v", occ.Path, err))
+            continue
+        }
+        dest := filepath.Join(dir, fmt.Sprintf("%d_%s", time.Now().UnixNano(), filepath.Base(occ.Path)))
+        if err := os.Rename(src, dest); err != nil {
+            lines = append(lines, fmt.Sprintf("ERR %s: %v", occ.Path, err))
+            continue
+        }
+
+        id := strconv.Itoa(s.nextID)
+        s.nextID++
+        s.quarantined[id] = quarantinedEntry{Occurrence: occ, Dest: dest}
+        lines = append(lines, fmt.Sprintf("%s %s", id, occ.Path))
+    }
+    return lines
+}
+
+func (s *ControlServer) handleRestore(args []string) []string {
+    if len(args) == 0 {
+        return []string{"ERR usage: RESTORE <id>"}
+    }
+    id := args[0]
+
+    s.mu.Lock()
+    entry, ok := s.quarantined[id]
+    if ok {
+        delete(s.quarantined, id)
+    }
+    s.mu.Unlock()
+
+    if !ok {
+        return []string{fmt.Sprintf("ERR unknown id %q", id)}
+    }
+
+    if err := os.MkdirAll(filepath.Dir(entry.Occurrence.Path), 0o755); err != nil {
+        return []string{fmt.Sprintf("ERR %v", err)}
+    }
+    if err := os.Rename(entry.Dest, entry.Occurrence.Path); err != nil {
+        return []string{fmt.Sprintf("ERR %v", err)}
+    }
+    return []string{"OK restored " + entry.Occurrence.Path}
+}
+
+func (s *ControlServer) handleList() []string {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    lines := []string{fmt.Sprintf("OK %d", len(s.quarantined))}
+    for id, entry := range s.quarantined {
+        lines = append(lines, fmt.Sprintf("%s %s", id, entry.Occurrence.Path))
+    }
+    return lines
+}
+
+// handlePatterns adds or removes a glob pattern from the live engine, so
+// the change is visible to every subsequent SCAN/QUARANTINE over the
+// socket (and, since the watch loop and control server share the same
+// *Engine, to the watch loop's own matching too) without recompiling or
+// restarting the daemon.
+func (s *ControlServer) handlePatterns(args []string) []string {
+    if len(args) < 2 {
+        return []string{"ERR usage: PATTERNS ADD|DEL <glob>"}
+    }
+
+    switch op, glob := strings.ToUpper(args[0]), args[1]; op {
+    case "ADD":
+        added, err := s.engine.AddGlob(glob)
+        if err != nil {
+            return []string{fmt.Sprintf("ERR %v", err)}
+        }
+        if !added {
+            return []string{"OK already present"}
+        }
+        return []string{"OK added " + glob}
+    case "DEL":
+        if s.engine.RemoveGlob(glob) {
+            return []string{"OK removed " + glob}
+        }
+        return []string{"OK not present"}
+    default:
+        return []string{fmt.Sprintf("ERR unknown PATTERNS op %q", op)}
+    }
+}
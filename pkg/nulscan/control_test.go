@@ -0,0 +1,242 @@
+package nulscan
+
+import (
+    "bufio"
+    "context"
+    "fmt"
+    "net"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+)
+
+// send writes a single request line to the control socket and returns the
+// response lines up to the blank terminator.
+func send(t *testing.T, sockPath, line string) []string {
+    t.Helper()
+
+    conn, err := net.Dial("unix", sockPath)
+    if err != nil {
+        t.Fatalf("dial %s: %v", sockPath, err)
+    }
+    defer conn.Close()
+
+    if _, err := fmt.Fprintln(conn, line); err != nil {
+        t.Fatalf("write %q: %v", line, err)
+    }
+
+    var lines []string
+    scanner := bufio.NewScanner(conn)
+    for scanner.Scan() {
+        text := scanner.Text()
+        if text == "" {
+            break
+        }
+        lines = append(lines, text)
+    }
+    if err := scanner.Err(); err != nil {
+        t.Fatalf("read response to %q: %v", line, err)
+    }
+    return lines
+}
+
+func startTestControlServer(t *testing.T, root string) *ControlServer {
+    t.Helper()
+
+    ctrl, err := NewControlServer(root, filepath.Join(root, "blocklist.rec"), filepath.Join(root, "quarantine"), "build-test", nil)
+    if err != nil {
+        t.Fatalf("NewControlServer: %v", err)
+    }
+    t.Cleanup(func() { ctrl.Close() })
+
+    ctx, cancel := context.WithCancel(context.Background())
+    t.Cleanup(cancel)
+    go ctrl.Serve(ctx)
+
+    return ctrl
+}
+
+func TestControlServerStatus(t *testing.T) {
+    root := t.TempDir()
+    ctrl := startTestControlServer(t, root)
+
+    lines := send(t, ctrl.SocketPath(), "STATUS")
+    if len(lines) == 0 || lines[0] != "OK" {
+        t.Fatalf("STATUS response = %v, want first line OK", lines)
+    }
+}
+
+func TestControlServerScanAndQuarantine(t *testing.T) {
+    root := t.TempDir()
+    if err := os.WriteFile(filepath.Join(root, "nul"), []byte("x"), 0o644); err != nil {
+        t.Fatalf("write fixture: %v", err)
+    }
+    ctrl := startTestControlServer(t, root)
+
+    scanLines := send(t, ctrl.SocketPath(), "SCAN "+root)
+    if len(scanLines) != 2 || scanLines[0] != "OK 1" {
+        t.Fatalf("SCAN response = %v, want [OK 1 <path>]", scanLines)
+    }
+
+    quarantineLines := send(t, ctrl.SocketPath(), "QUARANTINE "+root)
+    if len(quarantineLines) != 2 || quarantineLines[0] != "OK 1" {
+        t.Fatalf("QUARANTINE response = %v, want [OK 1 <id> <path>]", quarantineLines)
+    }
+    fields := strings.Fields(quarantineLines[1])
+    if len(fields) != 2 {
+        t.Fatalf("QUARANTINE entry = %q, want \"<id> <path>\"", quarantineLines[1])
+    }
+    id := fields[0]
+
+    if _, err := os.Stat(filepath.Join(root, "nul")); !os.IsNotExist(err) {
+        t.Fatalf("original file still present after QUARANTINE: err=%v", err)
+    }
+
+    listLines := send(t, ctrl.SocketPath(), "LIST")
+    if len(listLines) != 2 || listLines[0] != "OK 1" {
+        t.Fatalf("LIST response = %v, want [OK 1 <id> <path>]", listLines)
+    }
+
+    restoreLines := send(t, ctrl.SocketPath(), "RESTORE "+id)
+    if len(restoreLines) != 1 || !strings.HasPrefix(restoreLines[0], "OK restored") {
+        t.Fatalf("RESTORE response = %v, want [OK restored ...]", restoreLines)
+    }
+    if _, err := os.Stat(filepath.Join(root, "nul")); err != nil {
+        t.Fatalf("restored file missing: %v", err)
+    }
+
+    // The id is single-use.
+    again := send(t, ctrl.SocketPath(), "RESTORE "+id)
+    if len(again) != 1 || !strings.HasPrefix(again[0], "ERR") {
+        t.Fatalf("second RESTORE of the same id = %v, want an ERR", again)
+    }
+}
+
+func TestControlServerPatterns(t *testing.T) {
+    root := t.TempDir()
+    if err := os.WriteFile(filepath.Join(root, "secret.key"), []byte("x"), 0o644); err != nil {
+        t.Fatalf("write fixture: %v", err)
+    }
+    ctrl := startTestControlServer(t, root)
+
+    // Before PATTERNS ADD, the default reserved-name-only engine doesn't
+    // match secret.key.
+    if lines := send(t, ctrl.SocketPath(), "SCAN "+root); len(lines) != 1 || lines[0] != "OK 0" {
+        t.Fatalf("SCAN before PATTERNS ADD = %v, want [OK 0]", lines)
+    }
+
+    if lines := send(t, ctrl.SocketPath(), "PATTERNS ADD **/secret.key"); len(lines) != 1 || lines[0] != "OK added **/secret.key" {
+        t.Fatalf("PATTERNS ADD response = %v", lines)
+    }
+
+    if lines := send(t, ctrl.SocketPath(), "SCAN "+root); len(lines) != 2 || lines[0] != "OK 1" {
+        t.Fatalf("SCAN after PATTERNS ADD = %v, want [OK 1 <path>]", lines)
+    }
+
+    if lines := send(t, ctrl.SocketPath(), "PATTERNS DEL **/secret.key"); len(lines) != 1 || lines[0] != "OK removed **/secret.key" {
+        t.Fatalf("PATTERNS DEL response = %v", lines)
+    }
+
+    if lines := send(t, ctrl.SocketPath(), "SCAN "+root); len(lines) != 1 || lines[0] != "OK 0" {
+        t.Fatalf("SCAN after PATTERNS DEL = %v, want [OK 0]", lines)
+    }
+}
+
+func TestControlServerTrackQuarantine(t *testing.T) {
+    root := t.TempDir()
+    ctrl := startTestControlServer(t, root)
+
+    id := ctrl.TrackQuarantine(Occurrence{Path: filepath.Join(root, "nul")}, filepath.Join(root, "quarantine", "nul"))
+
+    lines := send(t, ctrl.SocketPath(), "LIST")
+    if len(lines) != 2 || lines[0] != "OK 1" || !strings.HasPrefix(lines[1], id+" ") {
+        t.Fatalf("LIST after TrackQuarantine = %v, want to include id %s", lines, id)
+    }
+}
+
+func TestControlServerUnknownVerb(t *testing.T) {
+    root := t.TempDir()
+    ctrl := startTestControlServer(t, root)
+
+    lines := send(t, ctrl.SocketPath(), "BOGUS")
+    if len(lines) != 1 || !strings.HasPrefix(lines[0], "ERR") {
+        t.Fatalf("unknown verb response = %v, want an ERR", lines)
+    }
+}
+
+func TestControlServerPublishesSocketPathFile(t *testing.T) {
+    root := t.TempDir()
+    quarantineRoot := filepath.Join(root, "quarantine")
+    ctrl, err := NewControlServer(root, filepath.Join(root, "blocklist.rec"), quarantineRoot, "build-test", nil)
+    if err != nil {
+        t.Fatalf("NewControlServer: %v", err)
+    }
+
+    wantPath := filepath.Join(quarantineRoot, socketLinkName(root))
+    if got := ctrl.SocketPathFile(); got != wantPath {
+        t.Fatalf("SocketPathFile() = %q, want %q", got, wantPath)
+    }
+
+    contents, err := os.ReadFile(wantPath)
+    if err != nil {
+        t.Fatalf("read %s: %v", wantPath, err)
+    }
+    if string(contents) != ctrl.SocketPath() {
+        t.Fatalf("%s contents = %q, want %q", wantPath, contents, ctrl.SocketPath())
+    }
+
+    ctrl.Close()
+    if _, err := os.Stat(wantPath); !os.IsNotExist(err) {
+        t.Fatalf("%s still present after Close: err=%v", wantPath, err)
+    }
+}
+
+func TestControlServerSharedQuarantineRootDoesNotCollide(t *testing.T) {
+    quarantineRoot := t.TempDir()
+    rootA := t.TempDir()
+    rootB := t.TempDir()
+
+    ctrlA, err := NewControlServer(rootA, filepath.Join(rootA, "blocklist.rec"), quarantineRoot, "build-test", nil)
+    if err != nil {
+        t.Fatalf("NewControlServer(A): %v", err)
+    }
+    defer ctrlA.Close()
+
+    ctrlB, err := NewControlServer(rootB, filepath.Join(rootB, "blocklist.rec"), quarantineRoot, "build-test", nil)
+    if err != nil {
+        t.Fatalf("NewControlServer(B): %v", err)
+    }
+    defer ctrlB.Close()
+
+    if ctrlA.SocketPathFile() == ctrlB.SocketPathFile() {
+        t.Fatalf("daemons watching different roots published the same discovery file %s", ctrlA.SocketPathFile())
+    }
+
+    // A's Close must not remove B's still-live discovery file.
+    ctrlA.Close()
+    contents, err := os.ReadFile(ctrlB.SocketPathFile())
+    if err != nil {
+        t.Fatalf("read B's discovery file after A.Close: %v", err)
+    }
+    if string(contents) != ctrlB.SocketPath() {
+        t.Fatalf("B's discovery file = %q after A.Close, want %q", contents, ctrlB.SocketPath())
+    }
+}
+
+func TestControlServerShutdown(t *testing.T) {
+    root := t.TempDir()
+    ctrl := startTestControlServer(t, root)
+
+    lines := send(t, ctrl.SocketPath(), "SHUTDOWN")
+    if len(lines) != 1 || lines[0] != "OK shutting down" {
+        t.Fatalf("SHUTDOWN response = %v", lines)
+    }
+
+    select {
+    case <-ctrl.Shutdown:
+    case <-time.After(time.Second):
+        t.Fatal("ctrl.Shutdown was not closed after SHUTDOWN")
+    }
+}
@@ -0,0 +1,19 @@
+//go:build !windows
+
+package nulscan
+
+import (
+    "os"
+    "syscall"
+)
+
+// lockFile takes a blocking, exclusive advisory lock on f via flock(2). The
+// returned func releases it.
+func lockFile(f *os.File) (unlock func() error, err error) {
+    if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+        return nil, err
+    }
+    return func() error {
+        return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+    }, nil
+}
@@ -0,0 +1,22 @@
+//go:build windows
+
+package nulscan
+
+import (
+    "os"
+
+    "golang.org/x/sys/windows"
+)
+
+// lockFile takes a blocking, exclusive advisory lock on f via LockFileEx.
+// The returned func releases it.
+func lockFile(f *os.File) (unlock func() error, err error) {
+    handle := windows.Handle(f.Fd())
+    overlapped := new(windows.Overlapped)
+    if err := windows.LockFileEx(handle, windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, overlapped); err != nil {
+        return nil, err
+    }
+    return func() error {
+        return windows.UnlockFileEx(handle, 0, 1, 0, overlapped)
+    }, nil
+}
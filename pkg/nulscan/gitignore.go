@@ -0,0 +1,45 @@
+package nulscan
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+// EnsureGitExclude adds the "**/nul" and "**/nul/*" globs to root's
+// .git/info/exclude so git never tracks them, even if a scan is skipped.
+// The other reserved device names (con, prn, aux, com*, lpt*) are not added.
+// If root isn't a git worktree, this is a silent no-op.
+func EnsureGitExclude(root string) error {
+    excludePath := filepath.Join(root, ".git", "info", "exclude")
+    if _, err := os.Stat(excludePath); err != nil {
+        return nil
+    }
+
+    data, err := os.ReadFile(excludePath)
+    if err != nil {
+        return err
+    }
+
+    content := string(data)
+    additions := []string{"**/nul", "**/nul/*"}
+    var builder strings.Builder
+    builder.WriteString(content)
+
+    changed := false
+    for _, add := range additions {
+        if !strings.Contains(content, add) {
+            if !strings.HasSuffix(builder.String(), "\n") {
+                builder.WriteString("\n")
+            }
+            builder.WriteString(add)
+            builder.WriteString("\n")
+            changed = true
+        }
+    }
+
+    if !changed {
+        return nil
+    }
+    return os.WriteFile(excludePath, []byte(builder.String()), 0o644)
+}
@@ -0,0 +1,273 @@
+package nulscan
+
+import (
+    "crypto/rand"
+    "fmt"
+    "io"
+    "os"
+    "path/filepath"
+    "time"
+
+    "github.com/GRID-INTELLIGENCE/GRID/internal/recfile"
+    "github.com/GRID-INTELLIGENCE/GRID/internal/tai64n"
+)
+
+// LedgerHeader is the single header record at the top of the blocklist
+// recfile, describing the pattern set active at the most recent run.
+type LedgerHeader struct {
+    GeneratedAt time.Time
+    Patterns    []string
+}
+
+// LedgerRecord is one historical occurrence. The recfile ledger is
+// append-only: every run adds new records and keeps all earlier ones, so
+// forensic history survives across runs. TAI64N is stored
+// alongside RFC3339 because it orders monotonically and is leap-second
+// safe, which matters when correlating events produced by many concurrent
+// worker deletions.
+type LedgerRecord struct {
+    Path       string
+    Parent     string
+    Top        string
+    DetectedAt time.Time
+    TAI64N     string
+    Action     string
+    BuildUUID  string
+}
+
+// EnsureBlocklist appends a LedgerRecord for each occ to blocklistPath,
+// creating the file with its header record if it doesn't exist yet.
+// Existing records are parsed first and deduplicated by Path+DetectedAt.
+// DetectedAt is taken once per call, so duplicates within one call collapse
+// to a single record, but a later call for the same occurrences gets a new
+// DetectedAt and appends them again. patterns is the resolved pattern set the run used
+// (see Engine.PatternStrings); pass nil to fall back to the legacy
+// literal-"nul" defaults.
+//
+// The read-modify-write is guarded by an exclusive lock on a sibling
+// ".lock" file, since writeLedger rewrites blocklistPath in full rather
+// than truly appending: without it, concurrent callers (e.g. CI systems
+// racing the same monorepo) can each read the same pre-write state and
+// the last writer's rewrite silently discards the others' records.
+func EnsureBlocklist(blocklistPath string, occs []Occurrence, action, buildUUID string, patterns []string) error {
+    if err := os.MkdirAll(filepath.Dir(blocklistPath), 0o755); err != nil {
+        return err
+    }
+
+    lf, err := os.OpenFile(blocklistPath+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+    if err != nil {
+        return fmt.Errorf("open ledger lock: %w", err)
+    }
+    defer lf.Close()
+
+    unlock, err := lockFile(lf)
+    if err != nil {
+        return fmt.Errorf("lock ledger: %w", err)
+    }
+    defer unlock()
+
+    header, existing, err := ReadLedger(blocklistPath)
+    if err != nil {
+        return err
+    }
+
+    header.GeneratedAt = time.Now().UTC()
+    header.Patterns = nil
+    if len(patterns) == 0 {
+        patterns = []string{"**/nul", "**/nul/*"}
+    }
+    for _, p := range patterns {
+        ensurePattern(&header, p)
+    }
+
+    seen := make(map[string]bool, len(existing))
+    for _, rec := range existing {
+        seen[rec.Path+"|"+rec.DetectedAt.Format(time.RFC3339Nano)] = true
+    }
+
+    now := time.Now().UTC()
+    nowTAI := tai64n.FromTime(now)
+
+    var fresh []LedgerRecord
+    for _, occ := range occs {
+        rec := LedgerRecord{
+            Path:       occ.Path,
+            Parent:     occ.Parent,
+            Top:        occ.Top,
+            DetectedAt: now,
+            TAI64N:     nowTAI.String(),
+            Action:     action,
+            BuildUUID:  buildUUID,
+        }
+        key := rec.Path + "|" + rec.DetectedAt.Format(time.RFC3339Nano)
+        if seen[key] {
+            continue
+        }
+        seen[key] = true
+        fresh = append(fresh, rec)
+    }
+
+    return writeLedger(blocklistPath, header, append(existing, fresh...))
+}
+
+func ensurePattern(header *LedgerHeader, pattern string) {
+    for _, existing := range header.Patterns {
+        if existing == pattern {
+            return
+        }
+    }
+    header.Patterns = append(header.Patterns, pattern)
+}
+
+// ReadLedger parses the existing recfile, returning a zero-value header
+// with the default pattern set if the file doesn't exist yet.
+func ReadLedger(blocklistPath string) (LedgerHeader, []LedgerRecord, error) {
+    header := LedgerHeader{Patterns: []string{"**/nul"}}
+
+    f, err := os.Open(blocklistPath)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return header, nil, nil
+        }
+        return header, nil, err
+    }
+    defer f.Close()
+
+    r := recfile.NewReader(f)
+    var records []LedgerRecord
+    first := true
+    for {
+        fields, err := r.Read()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return header, nil, fmt.Errorf("parse ledger %s: %w", blocklistPath, err)
+        }
+
+        if first {
+            first = false
+            if generated, ok := fields["GeneratedAt"]; ok {
+                if t, err := time.Parse(time.RFC3339Nano, generated); err == nil {
+                    header.GeneratedAt = t
+                }
+                header.Patterns = nil
+                for _, p := range splitPatterns(fields["Patterns"]) {
+                    ensurePattern(&header, p)
+                }
+                continue
+            }
+        }
+
+        detectedAt, _ := time.Parse(time.RFC3339Nano, fields["DetectedAt"])
+        records = append(records, LedgerRecord{
+            Path:       fields["Path"],
+            Parent:     fields["Parent"],
+            Top:        fields["Top"],
+            DetectedAt: detectedAt,
+            TAI64N:     fields["TAI64N"],
+            Action:     fields["Action"],
+            BuildUUID:  fields["BuildUUID"],
+        })
+    }
+    return header, records, nil
+}
+
+// writeLedger rewrites the header record in place and writes out every
+// record that should end up on disk. The ledger is still append-only from
+// a caller's perspective: records are never dropped, only the header is
+// refreshed.
+func writeLedger(blocklistPath string, header LedgerHeader, records []LedgerRecord) error {
+    f, err := os.OpenFile(blocklistPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+    if err != nil {
+        return err
+    }
+    defer f.Close()
+
+    w := recfile.NewWriter(f)
+    if err := w.Write(map[string]string{
+        "GeneratedAt": header.GeneratedAt.Format(time.RFC3339Nano),
+        "Patterns":    joinPatterns(header.Patterns),
+    }); err != nil {
+        return err
+    }
+
+    for _, rec := range records {
+        if err := w.Write(map[string]string{
+            "Path":       rec.Path,
+            "Parent":     rec.Parent,
+            "Top":        rec.Top,
+            "DetectedAt": rec.DetectedAt.Format(time.RFC3339Nano),
+            "TAI64N":     rec.TAI64N,
+            "Action":     rec.Action,
+            "BuildUUID":  rec.BuildUUID,
+        }); err != nil {
+            return err
+        }
+    }
+    return w.Flush()
+}
+
+func splitPatterns(joined string) []string {
+    if joined == "" {
+        return nil
+    }
+    var out []string
+    start := 0
+    for i := 0; i <= len(joined); i++ {
+        if i == len(joined) || joined[i] == ',' {
+            if i > start {
+                out = append(out, joined[start:i])
+            }
+            start = i + 1
+        }
+    }
+    return out
+}
+
+func joinPatterns(patterns []string) string {
+    out := ""
+    for i, p := range patterns {
+        if i > 0 {
+            out += ","
+        }
+        out += p
+    }
+    return out
+}
+
+// NewBuildUUID generates a random UUIDv4 to tag every ledger record
+// written by this process, so records from concurrent runs (e.g. CI jobs
+// racing the same monorepo) can be told apart.
+func NewBuildUUID() string {
+    var b [16]byte
+    if _, err := rand.Read(b[:]); err != nil {
+        return "unknown"
+    }
+    b[6] = (b[6] & 0x0f) | 0x40
+    b[8] = (b[8] & 0x3f) | 0x80
+    return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// FilterSince returns the records detected at or after the TAI64N label
+// sinceTAI, e.g. "@4000000037c219bf2ef02e94". An empty sinceTAI returns
+// every record.
+func FilterSince(records []LedgerRecord, sinceTAI string) ([]LedgerRecord, error) {
+    if sinceTAI == "" {
+        return records, nil
+    }
+    since, err := tai64n.Parse(sinceTAI)
+    if err != nil {
+        return nil, fmt.Errorf("parse since label: %w", err)
+    }
+
+    var out []LedgerRecord
+    for _, rec := range records {
+        label, err := tai64n.Parse(rec.TAI64N)
+        if err != nil || label.Before(since) {
+            continue
+        }
+        out = append(out, rec)
+    }
+    return out, nil
+}
@@ -0,0 +1,94 @@
+package nulscan
+
+import (
+    "fmt"
+    "path/filepath"
+    "sync"
+    "testing"
+)
+
+func TestEnsureBlocklistRoundTrip(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "blocklist.rec")
+    occs := []Occurrence{
+        {Path: "a/nul", Parent: "a", Top: "a"},
+        {Path: "b/c/nul", Parent: "b/c", Top: "b"},
+    }
+
+    if err := EnsureBlocklist(path, occs, "removed", "build-1", []string{"**/nul"}); err != nil {
+        t.Fatalf("EnsureBlocklist: %v", err)
+    }
+
+    header, records, err := ReadLedger(path)
+    if err != nil {
+        t.Fatalf("ReadLedger: %v", err)
+    }
+    if len(records) != len(occs) {
+        t.Fatalf("ReadLedger returned %d records, want %d", len(records), len(occs))
+    }
+    if len(header.Patterns) != 1 || header.Patterns[0] != "**/nul" {
+        t.Fatalf("header.Patterns = %v, want [**/nul]", header.Patterns)
+    }
+    for i, rec := range records {
+        if rec.Path != occs[i].Path || rec.Action != "removed" || rec.BuildUUID != "build-1" {
+            t.Errorf("record %d = %+v, want Path=%s Action=removed BuildUUID=build-1", i, rec, occs[i].Path)
+        }
+    }
+}
+
+func TestEnsureBlocklistDedup(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "blocklist.rec")
+
+    // The same occurrence listed twice in one call shares a single
+    // DetectedAt (computed once per call), so it collapses to one record.
+    occ := Occurrence{Path: "a/nul", Parent: "a", Top: "a"}
+    if err := EnsureBlocklist(path, []Occurrence{occ, occ}, "removed", "build-1", nil); err != nil {
+        t.Fatalf("EnsureBlocklist: %v", err)
+    }
+
+    _, records, err := ReadLedger(path)
+    if err != nil {
+        t.Fatalf("ReadLedger: %v", err)
+    }
+    if len(records) != 1 {
+        t.Fatalf("got %d records for a duplicated occurrence in one call, want 1 (dedup failed)", len(records))
+    }
+}
+
+func TestEnsureBlocklistConcurrent(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "blocklist.rec")
+
+    const n = 20
+    var wg sync.WaitGroup
+    for i := 0; i < n; i++ {
+        wg.Add(1)
+        go func(i int) {
+            defer wg.Done()
+            occ := Occurrence{Path: fmt.Sprintf("worker-%d/nul", i), Parent: fmt.Sprintf("worker-%d", i), Top: fmt.Sprintf("worker-%d", i)}
+            if err := EnsureBlocklist(path, []Occurrence{occ}, "removed", fmt.Sprintf("build-%d", i), []string{"**/nul"}); err != nil {
+                t.Errorf("EnsureBlocklist(worker-%d): %v", i, err)
+            }
+        }(i)
+    }
+    wg.Wait()
+
+    _, records, err := ReadLedger(path)
+    if err != nil {
+        t.Fatalf("ReadLedger: %v", err)
+    }
+    if len(records) != n {
+        t.Fatalf("got %d records after %d concurrent EnsureBlocklist calls, want %d (lost updates)", len(records), n, n)
+    }
+}
+
+func TestReadLedgerMissingFile(t *testing.T) {
+    header, records, err := ReadLedger(filepath.Join(t.TempDir(), "does-not-exist.rec"))
+    if err != nil {
+        t.Fatalf("ReadLedger on missing file: %v", err)
+    }
+    if records != nil {
+        t.Errorf("records = %v, want nil", records)
+    }
+    if len(header.Patterns) != 1 || header.Patterns[0] != "**/nul" {
+        t.Errorf("header.Patterns = %v, want default [**/nul]", header.Patterns)
+    }
+}
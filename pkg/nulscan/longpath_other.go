@@ -0,0 +1,9 @@
+//go:build !windows
+
+package nulscan
+
+// toExtendedPath is a no-op off Windows: the `\\?\` long-path prefix is a
+// Win32-only convention for bypassing reserved-device-name interception.
+func toExtendedPath(path string) (string, error) {
+    return path, nil
+}
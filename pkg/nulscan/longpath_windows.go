@@ -0,0 +1,31 @@
+//go:build windows
+
+package nulscan
+
+import (
+    "path/filepath"
+    "strings"
+)
+
+// toExtendedPath converts path to its `\\?\`-prefixed form so it can be
+// opened, renamed, or removed via os.RemoveAll even when it's one of the
+// reserved DOS device names (nul, con, prn, aux, com1..9, lpt1..9). The
+// Win32 kernel intercepts those names before they reach the filesystem
+// unless the `\\?\` prefix bypasses its name parsing, so without this an
+// os.RemoveAll on path silently fails to touch the artifact this tool
+// exists to clean up. UNC paths get the `\\?\UNC\` form instead.
+func toExtendedPath(path string) (string, error) {
+    if strings.HasPrefix(path, `\\?\`) {
+        return path, nil
+    }
+
+    abs, err := filepath.Abs(path)
+    if err != nil {
+        return "", err
+    }
+
+    if strings.HasPrefix(abs, `\\`) {
+        return `\\?\UNC\` + strings.TrimPrefix(abs, `\\`), nil
+    }
+    return `\\?\` + abs, nil
+}
@@ -0,0 +1,61 @@
+//go:build windows
+
+package nulscan
+
+import (
+    "context"
+    "os"
+    "path/filepath"
+    "testing"
+
+    "golang.org/x/sys/windows"
+)
+
+// TestCreateFileWBackupSemanticsFabricatesReservedName uses CreateFileW
+// with FILE_FLAG_BACKUP_SEMANTICS against a `\\?\`-prefixed path to create
+// a literal file named "nul" on disk — something os.Create can't do,
+// since the Win32 kernel intercepts reserved device names before they
+// reach the filesystem unless the `\\?\` prefix bypasses its name
+// parsing. That gives this test a real on-disk "nul" artifact to exercise
+// toExtendedPath and DeleteAction against, instead of relying on the
+// reserved device actually colliding with a test fixture path.
+func TestCreateFileWBackupSemanticsFabricatesReservedName(t *testing.T) {
+    dir := t.TempDir()
+    path := filepath.Join(dir, "nul")
+
+    extended, err := toExtendedPath(path)
+    if err != nil {
+        t.Fatalf("toExtendedPath(%s): %v", path, err)
+    }
+
+    namePtr, err := windows.UTF16PtrFromString(extended)
+    if err != nil {
+        t.Fatalf("UTF16PtrFromString(%s): %v", extended, err)
+    }
+
+    handle, err := windows.CreateFile(
+        namePtr,
+        windows.GENERIC_READ|windows.GENERIC_WRITE,
+        0,
+        nil,
+        windows.CREATE_NEW,
+        windows.FILE_FLAG_BACKUP_SEMANTICS,
+        0,
+    )
+    if err != nil {
+        t.Fatalf("CreateFile(%s): %v", extended, err)
+    }
+    windows.CloseHandle(handle)
+
+    if _, err := os.Lstat(path); err != nil {
+        t.Fatalf("fabricated %s not visible via Lstat: %v", path, err)
+    }
+
+    if _, _, err := (DeleteAction{}).Apply(context.Background(), Occurrence{Path: path}); err != nil {
+        t.Fatalf("DeleteAction.Apply(%s): %v", path, err)
+    }
+
+    if _, err := os.Lstat(path); !os.IsNotExist(err) {
+        t.Fatalf("fabricated %s still present after DeleteAction: err=%v", path, err)
+    }
+}
@@ -0,0 +1,253 @@
+package nulscan
+
+import (
+    "fmt"
+    "os"
+    "regexp"
+    "strings"
+    "sync"
+
+    "github.com/bmatcuk/doublestar/v4"
+    "gopkg.in/yaml.v3"
+)
+
+// PatternKind selects how a Pattern's Value is interpreted.
+type PatternKind string
+
+const (
+    // KindGlob matches Value as a doublestar glob ("**/nul", "**/com[0-9]")
+    // against the path relative to the scan root.
+    KindGlob PatternKind = "glob"
+    // KindRegex matches Value as an anchored regular expression against
+    // the same relative path.
+    KindRegex PatternKind = "regex"
+    // KindReserved matches the full Windows CSIDL reserved-device-name
+    // set (nul, con, prn, aux, com1-9, lpt1-9), normalizing away the
+    // trailing dot/space Windows itself ignores ("nul.txt", "nul " both
+    // match "nul"). Value is unused for this kind.
+    KindReserved PatternKind = "reserved"
+)
+
+// Pattern is one entry in the pattern engine: a match rule plus the
+// metadata an operator wants recorded when it fires.
+type Pattern struct {
+    Kind     PatternKind `yaml:"kind"`
+    Value    string      `yaml:"value"`
+    Action   string      `yaml:"action"`
+    Severity string      `yaml:"severity"`
+    Reason   string      `yaml:"reason"`
+
+    re *regexp.Regexp
+}
+
+// patternsFile is the on-disk shape of patterns.yaml.
+type patternsFile struct {
+    Patterns []Pattern `yaml:"patterns"`
+}
+
+var reservedDeviceNames = buildReservedDeviceNames()
+
+func buildReservedDeviceNames() map[string]bool {
+    names := map[string]bool{"nul": true, "con": true, "prn": true, "aux": true}
+    for d := '1'; d <= '9'; d++ {
+        names["com"+string(d)] = true
+        names["lpt"+string(d)] = true
+    }
+    return names
+}
+
+// normalizeReservedName lowercases name and strips the trailing dot/space
+// and any extension Windows ignores when resolving a reserved device
+// name, so "NUL.txt" and "nul " both normalize to "nul".
+func normalizeReservedName(name string) string {
+    name = strings.ToLower(strings.TrimRight(name, " ."))
+    if idx := strings.IndexByte(name, '.'); idx >= 0 {
+        name = name[:idx]
+    }
+    return name
+}
+
+// isReservedDeviceName reports whether name resolves to one of the
+// Windows CSIDL reserved device names under normalizeReservedName.
+func isReservedDeviceName(name string) bool {
+    return reservedDeviceNames[normalizeReservedName(name)]
+}
+
+// Compile validates p, rejecting malformed glob/regex patterns with a
+// clear error at load time instead of having them silently match nothing
+// at scan time.
+func (p *Pattern) Compile() error {
+    switch p.Kind {
+    case KindGlob:
+        if !doublestar.ValidatePattern(p.Value) {
+            return fmt.Errorf("pattern %q: invalid glob", p.Value)
+        }
+    case KindRegex:
+        re, err := regexp.Compile("^(?:" + p.Value + ")$")
+        if err != nil {
+            return fmt.Errorf("pattern %q: invalid regex: %w", p.Value, err)
+        }
+        p.re = re
+    case KindReserved, "":
+        p.Kind = KindReserved
+    default:
+        return fmt.Errorf("pattern %q: unknown kind %q", p.Value, p.Kind)
+    }
+    return nil
+}
+
+// Match reports whether p fires for a file named name at path rel
+// (slash-separated, relative to the scan root).
+func (p Pattern) Match(rel, name string) bool {
+    switch p.Kind {
+    case KindGlob:
+        ok, _ := doublestar.Match(p.Value, rel)
+        return ok
+    case KindRegex:
+        return p.re != nil && p.re.MatchString(rel)
+    case KindReserved:
+        return isReservedDeviceName(name)
+    default:
+        return false
+    }
+}
+
+// Engine is an ordered set of compiled Patterns. The mutex guards Patterns
+// so a daemon's control socket can append or remove rules (PATTERNS
+// ADD|DEL) while the watch loop is concurrently matching against the same
+// *Engine.
+type Engine struct {
+    Patterns []Pattern
+
+    mu sync.Mutex
+}
+
+// DefaultEngine returns the built-in reserved-device-name pattern,
+// equivalent to the scanner's historical literal-"nul" behavior but
+// covering the full CSIDL set. Used when no patterns.yaml is configured.
+func DefaultEngine() *Engine {
+    return &Engine{Patterns: []Pattern{{
+        Kind:     KindReserved,
+        Action:   "removed",
+        Severity: "high",
+        Reason:   "reserved Windows device name",
+    }}}
+}
+
+// LoadPatterns reads and validates a patterns.yaml file. Malformed glob
+// or regex entries fail the load with a descriptive error so operators
+// catch typos in organization-specific blocklists before they ship one
+// that silently matches nothing.
+func LoadPatterns(path string) (*Engine, error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, err
+    }
+
+    var pf patternsFile
+    if err := yaml.Unmarshal(data, &pf); err != nil {
+        return nil, fmt.Errorf("parse %s: %w", path, err)
+    }
+
+    for i := range pf.Patterns {
+        if err := pf.Patterns[i].Compile(); err != nil {
+            return nil, fmt.Errorf("%s: %w", path, err)
+        }
+    }
+    return &Engine{Patterns: pf.Patterns}, nil
+}
+
+// Matcher adapts e to the bare-name Matcher signature the filesystem
+// watcher needs, since it sees one created path at a time rather than a
+// path relative to a scan root. Reserved-name patterns behave exactly as
+// MatchPath; glob/regex patterns are matched against the bare file name
+// only, so a glob like "**/sub/nul" won't fire from watch mode the way it
+// would from a full MatchPath-driven sweep.
+func (e *Engine) Matcher() Matcher {
+    return func(name string) bool {
+        _, ok := e.MatchPath(name, name)
+        return ok
+    }
+}
+
+// MatchPath reports whether rel matches any pattern in e, returning the
+// first one that fires.
+func (e *Engine) MatchPath(rel, name string) (Pattern, bool) {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+    for _, p := range e.Patterns {
+        if p.Match(rel, name) {
+            return p, true
+        }
+    }
+    return Pattern{}, false
+}
+
+// PatternStrings returns a human-readable form of each pattern's rule,
+// for persisting alongside the ledger header so a run's resolved pattern
+// set is recoverable from the ledger alone.
+func (e *Engine) PatternStrings() []string {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+    out := make([]string, 0, len(e.Patterns))
+    for _, p := range e.Patterns {
+        if p.Kind == KindReserved {
+            out = append(out, "reserved-device-name")
+            continue
+        }
+        out = append(out, string(p.Kind)+":"+p.Value)
+    }
+    return out
+}
+
+// AddGlob compiles and appends a glob Pattern to e, returning false
+// without modifying e if value is already present. Safe to call while
+// other goroutines are matching against e.
+func (e *Engine) AddGlob(value string) (bool, error) {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+
+    for _, p := range e.Patterns {
+        if p.Kind == KindGlob && p.Value == value {
+            return false, nil
+        }
+    }
+    p := Pattern{Kind: KindGlob, Value: value, Action: "quarantined", Reason: "added over control socket"}
+    if err := p.Compile(); err != nil {
+        return false, err
+    }
+    e.Patterns = append(e.Patterns, p)
+    return true, nil
+}
+
+// RemoveGlob removes the glob Pattern matching value from e, reporting
+// whether one was found.
+func (e *Engine) RemoveGlob(value string) bool {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+
+    for i, p := range e.Patterns {
+        if p.Kind == KindGlob && p.Value == value {
+            e.Patterns = append(e.Patterns[:i], e.Patterns[i+1:]...)
+            return true
+        }
+    }
+    return false
+}
+
+// Snapshot returns a copy of e's current Patterns, safe to range over
+// while e is concurrently mutated.
+func (e *Engine) Snapshot() []Pattern {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+    out := make([]Pattern, len(e.Patterns))
+    copy(out, e.Patterns)
+    return out
+}
+
+// Len reports the number of patterns currently in e.
+func (e *Engine) Len() int {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+    return len(e.Patterns)
+}
@@ -0,0 +1,111 @@
+package nulscan
+
+import (
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+func TestNormalizeReservedName(t *testing.T) {
+    cases := map[string]string{
+        "nul":      "nul",
+        "NUL":      "nul",
+        "nul.txt":  "nul",
+        "nul ":     "nul",
+        "nul. ":    "nul",
+        "com1.log": "com1",
+    }
+    for in, want := range cases {
+        if got := normalizeReservedName(in); got != want {
+            t.Errorf("normalizeReservedName(%q) = %q, want %q", in, got, want)
+        }
+    }
+}
+
+func TestIsReservedDeviceName(t *testing.T) {
+    for _, name := range []string{"nul", "NUL.txt", "con ", "com9", "lpt1.log"} {
+        if !isReservedDeviceName(name) {
+            t.Errorf("isReservedDeviceName(%q) = false, want true", name)
+        }
+    }
+    for _, name := range []string{"null", "com0", "lpt0", "com10", "lpt0x", "readme.md"} {
+        if isReservedDeviceName(name) {
+            t.Errorf("isReservedDeviceName(%q) = true, want false", name)
+        }
+    }
+}
+
+func TestPatternCompileInvalid(t *testing.T) {
+    p := Pattern{Kind: KindRegex, Value: "("}
+    if err := p.Compile(); err == nil {
+        t.Error("Compile with invalid regex: expected error, got nil")
+    }
+
+    p = Pattern{Kind: "bogus"}
+    if err := p.Compile(); err == nil {
+        t.Error("Compile with unknown kind: expected error, got nil")
+    }
+}
+
+func TestEngineMatchPath(t *testing.T) {
+    e := &Engine{Patterns: []Pattern{
+        {Kind: KindGlob, Value: "**/com[0-9]"},
+        {Kind: KindReserved},
+    }}
+    for i := range e.Patterns {
+        if err := e.Patterns[i].Compile(); err != nil {
+            t.Fatalf("Compile pattern %d: %v", i, err)
+        }
+    }
+
+    if _, ok := e.MatchPath("a/b/com3", "com3"); !ok {
+        t.Error("MatchPath(a/b/com3): expected glob match")
+    }
+    if _, ok := e.MatchPath("a/nul.txt", "nul.txt"); !ok {
+        t.Error("MatchPath(a/nul.txt): expected reserved-name match")
+    }
+    if _, ok := e.MatchPath("a/readme.md", "readme.md"); ok {
+        t.Error("MatchPath(a/readme.md): expected no match")
+    }
+}
+
+func TestEngineAddRemoveGlob(t *testing.T) {
+    e := DefaultEngine()
+
+    added, err := e.AddGlob("**/secret.key")
+    if err != nil {
+        t.Fatalf("AddGlob: %v", err)
+    }
+    if !added {
+        t.Fatal("AddGlob: expected true for a new pattern")
+    }
+    if added, err = e.AddGlob("**/secret.key"); err != nil || added {
+        t.Fatalf("AddGlob duplicate: added=%v err=%v, want false, nil", added, err)
+    }
+
+    if _, ok := e.MatchPath("a/secret.key", "secret.key"); !ok {
+        t.Error("MatchPath after AddGlob: expected match")
+    }
+
+    if !e.RemoveGlob("**/secret.key") {
+        t.Error("RemoveGlob: expected true removing a present pattern")
+    }
+    if e.RemoveGlob("**/secret.key") {
+        t.Error("RemoveGlob: expected false removing an already-removed pattern")
+    }
+    if _, ok := e.MatchPath("a/secret.key", "secret.key"); ok {
+        t.Error("MatchPath after RemoveGlob: expected no match")
+    }
+}
+
+func TestLoadPatternsRejectsInvalidGlob(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "patterns.yaml")
+    data := "patterns:\n  - kind: glob\n    value: \"[\"\n"
+    if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+        t.Fatalf("WriteFile: %v", err)
+    }
+
+    if _, err := LoadPatterns(path); err == nil {
+        t.Error("LoadPatterns with an invalid glob: expected error, got nil")
+    }
+}
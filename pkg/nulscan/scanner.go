@@ -0,0 +1,124 @@
+// Package nulscan is the scan/clean/quarantine/watch/report engine behind
+// the grid-nul CLI. It replaces the two standalone programs this repo used
+// to carry (a flag-driven one-shot scanner and an interactive security
+// monitor) with a single library the cmd/grid-nul subcommands share.
+package nulscan
+
+import (
+    "context"
+    "io/fs"
+    "path/filepath"
+    "strings"
+)
+
+// Occurrence captures a single reserved-name artifact discovery.
+type Occurrence struct {
+    Path   string
+    Parent string
+    Top    string
+}
+
+// Matcher reports whether a file name should be treated as a reserved-name
+// artifact worth surfacing as an Occurrence.
+type Matcher func(name string) bool
+
+// DefaultMatcher matches the full Windows CSIDL reserved-device-name set
+// (nul, con, prn, aux, com1-9, lpt1-9), normalizing away the trailing
+// dot/space and extension Windows itself ignores, so "nul.txt" and
+// "nul " both match "nul". Used by callers that don't wire up the
+// pattern engine via Scanner.Engine.
+func DefaultMatcher(name string) bool { return isReservedDeviceName(name) }
+
+// Scanner walks a directory tree looking for Occurrences. If Engine is
+// set, every pattern in it is evaluated against each file's path relative
+// to Root; otherwise Matcher (DefaultMatcher if nil) is evaluated against
+// the bare file name.
+type Scanner struct {
+    Root    string
+    Matcher Matcher
+    Engine  *Engine
+}
+
+// NewScanner builds a Scanner rooted at root using DefaultMatcher.
+func NewScanner(root string) *Scanner {
+    return &Scanner{Root: root, Matcher: DefaultMatcher}
+}
+
+// Scan performs a single filepath.WalkDir sweep of s.Root.
+func (s *Scanner) Scan(ctx context.Context) ([]Occurrence, error) {
+    matcher := s.Matcher
+    if matcher == nil && s.Engine == nil {
+        matcher = DefaultMatcher
+    }
+
+    var occs []Occurrence
+    err := filepath.WalkDir(s.Root, func(path string, d fs.DirEntry, walkErr error) error {
+        if walkErr != nil {
+            return walkErr
+        }
+        select {
+        case <-ctx.Done():
+            return ctx.Err()
+        default:
+        }
+
+        if d == nil {
+            return nil
+        }
+
+        rel, err := filepath.Rel(s.Root, path)
+        if err != nil {
+            rel = path
+        }
+
+        switch {
+        case s.Engine != nil:
+            if _, ok := s.Engine.MatchPath(filepath.ToSlash(rel), d.Name()); !ok {
+                return nil
+            }
+        case !matcher(d.Name()):
+            return nil
+        }
+
+        parent := filepath.Dir(rel)
+        if parent == "." {
+            parent = s.Root
+        }
+        occs = append(occs, Occurrence{Path: path, Parent: parent, Top: rootComponent(rel)})
+        return nil
+    })
+    return occs, err
+}
+
+func rootComponent(rel string) string {
+    rel = filepath.ToSlash(rel)
+    if idx := strings.Index(rel, "/"); idx > 0 {
+        return rel[:idx]
+    }
+    return rel
+}
+
+// SummarizeSources logs (via the caller-supplied logf) the top-level
+// sources and heaviest parent directories contributing to occs. It's
+// shared by the one-shot scan path and the incremental watch path so both
+// report in the same shape.
+func SummarizeSources(occs []Occurrence, logf func(format string, args ...interface{})) {
+    sourceCount := make(map[string]int)
+    parents := make(map[string]int)
+    for _, occ := range occs {
+        sourceCount[occ.Top]++
+        parents[occ.Parent]++
+    }
+
+    logf("Top-level sources:")
+    for top, count := range sourceCount {
+        logf("  %s -> %d", top, count)
+    }
+
+    logf("Heaviest parent directories:")
+    for parent, count := range parents {
+        if count > 10 {
+            logf("  %s -> %d", parent, count)
+        }
+    }
+}
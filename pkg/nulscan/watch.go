@@ -0,0 +1,53 @@
+package nulscan
+
+import "github.com/GRID-INTELLIGENCE/GRID/internal/fswatch"
+
+// WatchEvent carries either an Occurrence surfaced by Watch or, with
+// Occurrence left zero, a non-fatal error the underlying watcher reported.
+type WatchEvent struct {
+    Occurrence Occurrence
+    Err        error
+}
+
+// Watch starts a recursive filesystem watch rooted at root using matcher
+// (DefaultMatcher if nil) and streams Occurrences as they're created. The
+// returned stop func releases the watcher's resources.
+func Watch(root string, matcher Matcher) (<-chan WatchEvent, func() error, error) {
+    if matcher == nil {
+        matcher = DefaultMatcher
+    }
+
+    w, err := fswatch.New(fswatch.Options{Matcher: fswatch.Matcher(matcher)})
+    if err != nil {
+        return nil, nil, err
+    }
+    if err := w.Add(root); err != nil {
+        w.Close()
+        return nil, nil, err
+    }
+
+    out := make(chan WatchEvent)
+    go func() {
+        defer close(out)
+        events := w.Events()
+        errs := w.Errors()
+        for events != nil || errs != nil {
+            select {
+            case occ, ok := <-events:
+                if !ok {
+                    events = nil
+                    continue
+                }
+                out <- WatchEvent{Occurrence: Occurrence{Path: occ.Path, Parent: occ.Parent, Top: occ.Top}}
+            case err, ok := <-errs:
+                if !ok {
+                    errs = nil
+                    continue
+                }
+                out <- WatchEvent{Err: err}
+            }
+        }
+    }()
+
+    return out, w.Close, nil
+}